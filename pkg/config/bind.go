@@ -0,0 +1,180 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/boolka/goconfig/pkg/datamap"
+	goconfigLogger "github.com/boolka/goconfig/pkg/logger"
+)
+
+const bindTag = "goconfig"
+
+// FieldError describes a struct field that could not be bound by Bind.
+type FieldError struct {
+	Field string
+	Path  string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("field %s (path %s): %s", e.Field, e.Path, e.Err.Error())
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Bind fills the struct pointed to by dst with configuration values. Fields are
+// mapped by the `goconfig:"path.to.field"` tag relative to prefix, every leaf is
+// looked up independently with the usual sources precedence. Struct fields are
+// descended into with their tag appended to prefix, untagged embedded structs
+// share the parent prefix. A field tagged with ",optional" keeps its value if the
+// path does not exist. All missing and mistyped fields are reported at once as
+// joined *FieldError values.
+func (c *Config) Bind(ctx context.Context, prefix string, dst any) error {
+	if c.logger != nil {
+		ctx = goconfigLogger.ContextWithLogger(ctx, c.logger)
+		c.logger.DebugContext(ctx, fmt.Sprintf("bind %s prefix to %T", prefix, dst))
+	}
+
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("bind destination must be a non nil pointer to struct, got %T", dst)
+	}
+
+	var errs []error
+	refs := map[string][]reference{}
+	files := map[string]string{}
+
+	bindStruct(rv.Elem(), prefix, "", func(path string) (any, bool) {
+		if err := ctx.Err(); err != nil {
+			return err, false
+		}
+
+		abs := c.abs(path)
+		res := search(ctx, c.state.snapshot(), []string{normalizePath(abs)}, abs)
+		refs[path] = res.refs
+		files[path] = sourceFile(res)
+
+		return res.v, res.ok
+	}, &errs)
+
+	// point conversion errors to the files and do not leak sensitive values through them
+	for _, err := range errs {
+		var typeErr *TypeError
+		if errors.As(err, &typeErr) {
+			typeErr.File = files[typeErr.Path]
+			typeErr.Value = c.redact(ctx, datamap.SplitPath(c.abs(typeErr.Path)), typeErr.Value, refs[typeErr.Path])
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// walk struct fields and resolve tagged leafs with lookup function
+func bindStruct(rv reflect.Value, prefix, fieldPrefix string, lookup func(string) (any, bool), errs *[]error) {
+	rt := rv.Type()
+
+	for i := range rt.NumField() {
+		field := rt.Field(i)
+
+		// unexported embedded structs still promote their exported fields
+		if !field.IsExported() && !(field.Anonymous && field.Type.Kind() == reflect.Struct) {
+			continue
+		}
+
+		tag, hasTag := field.Tag.Lookup(bindTag)
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		optional := opts == "optional"
+		fieldName := joinPath(fieldPrefix, field.Name)
+		fv := rv.Field(i)
+
+		if !hasTag || name == "" {
+			if field.Anonymous && isNested(field.Type) {
+				bindNested(fv, prefix, fieldName, lookup, errs)
+			}
+
+			continue
+		}
+
+		path := joinPath(prefix, name)
+
+		if isNested(field.Type) {
+			bindNested(fv, path, fieldName, lookup, errs)
+			continue
+		}
+
+		v, ok := lookup(path)
+		if !ok {
+			if err, isErr := v.(error); isErr {
+				*errs = append(*errs, &FieldError{Field: fieldName, Path: path, Err: err})
+			} else if !optional {
+				*errs = append(*errs, &FieldError{Field: fieldName, Path: path, Err: ErrNotFound})
+			}
+
+			continue
+		}
+
+		cv, err := convert(v, field.Type)
+		if err != nil {
+			if err == errUnsupported {
+				err = nil
+			}
+
+			*errs = append(*errs, &FieldError{
+				Field: fieldName,
+				Path:  path,
+				Err: &TypeError{
+					Path:  path,
+					Value: v,
+					Type:  field.Type,
+					Err:   err,
+				},
+			})
+
+			continue
+		}
+
+		fv.Set(cv)
+	}
+}
+
+func bindNested(fv reflect.Value, prefix, fieldName string, lookup func(string) (any, bool), errs *[]error) {
+	if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+
+		fv = fv.Elem()
+	}
+
+	bindStruct(fv, prefix, fieldName, lookup, errs)
+}
+
+// struct (or pointer to struct) types that are not decoded from values are bound field by field
+func isNested(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Struct && t != urlType && !reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+func joinPath(prefix, path string) string {
+	switch {
+	case prefix == "":
+		return path
+	case path == "":
+		return prefix
+	}
+
+	return prefix + "." + path
+}
@@ -6,15 +6,36 @@ import (
 	"io/fs"
 	"log/slog"
 	"os"
+	"regexp"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/boolka/goconfig/pkg/datamap"
 	"github.com/boolka/goconfig/pkg/env"
 	"github.com/boolka/goconfig/pkg/file"
 	goconfigLogger "github.com/boolka/goconfig/pkg/logger"
+	"github.com/boolka/goconfig/pkg/memory"
+	"github.com/boolka/goconfig/pkg/schema"
 	"github.com/boolka/goconfig/pkg/source"
 	vault "github.com/boolka/goconfig/pkg/vault"
 )
 
+// Layout defines how configuration directory is organized
+type Layout int
+
+const (
+	// LayoutFiles is the default. Configuration files are placed right in the directory, subdirectories are ignored.
+	LayoutFiles Layout = iota
+	// LayoutDirs loads files of subdirectories too. Directory name is recognized like filename,
+	// so "production/database.yaml" is treated as a part of "production.yaml". Directories prefixed with "_"
+	// hold included fragments and are skipped.
+	LayoutDirs
+	// LayoutNamespacedDirs is the same as LayoutDirs except that keys of every file inside subdirectory
+	// are nested beneath its basename, so "production/database.yaml" contributes "database.*" keys.
+	LayoutNamespacedDirs
+)
+
 // Config options:
 //
 //   - Directory: path to config files
@@ -24,7 +45,8 @@ import (
 //
 //   - Instance: is concrete instance number in multi instance deployments
 //
-//   - Deployment: is concrete deployment. For example "production" or "development"
+//   - Deployment: is concrete deployment. For example "production" or "development". Comma delimited list
+//     like "production,eu,canary" activates several deployments at once, the later ones take precedence.
 //
 //   - Hostname: mean current machine hostname
 //
@@ -32,20 +54,79 @@ import (
 //
 //   - VaultClient: [vault] client. To use vault api set "vault" build tag. Otherwise it would act as plain file.
 //
+//   - Watch: poll configuration files for changes and reload them. Call Close to stop watching.
+//
+//   - WatchInterval: files polling interval. Defaults to 10 seconds.
+//
+//   - OnReloadError: receives errors of failed reloads. The last successfully loaded configuration stays in use.
+//
+//   - Schema: the effective configuration is validated against it on loading. *schema.ValidationError lists all the violations.
+//
+//   - InstancePattern: matches instance identifiers in filenames, defaults to numbers. Use it for named shards like "default-eu1".
+//
+//   - Precedence: source types from the highest to the lowest. It must list every file source type and tier exactly once.
+//
+//   - Tiers: names of additional file layers. Files named "{tier}.EXT" are placed beneath environment by default, see source.TierType.
+//
+//   - Dimensions: ordered name/value pairs of additional configuration axes (datacenter, zone, tenant and etc.).
+//     File "{name}@{dimension}={value}.EXT" is used only if the value matches and is placed above "{name}.EXT".
+//
+//   - Layout: directory organization, see Layout constants.
+//
+//   - Strict: fail if some file is of unregistered format or its name does not match current or known
+//     deployments, instances and dimensions (mistyped "prodution.yaml" for example). Such files are
+//     logged as warnings otherwise if KnownDeployments are set.
+//
+//   - KnownDeployments: valid deployments besides the current ones. Their files are recognized but not loaded,
+//     files of other deployments are logged as warnings.
+//
+//   - KnownInstances: valid instances besides the current one. If empty then any instance matching InstancePattern is valid.
+//
+//   - DuplicatePolicy: how sources of the same layer ("default.json" and "default.yaml" for example) are treated,
+//     see DuplicatePolicy constants.
+//
+//   - Sources: custom sources (in-house secret stores and etc.). Source Originer is consulted at the position of
+//     the source Tier which must be declared by Tiers option, beneath files of the tier. FilePath names the source.
+//
+//   - Constructors: originer constructors of "{tier}.EXT" files keyed by the tier name declared by Tiers option.
+//     Files of other tiers are decoded as plain configuration files.
+//
+//   - Sensitive: path patterns (wildcards allowed) which values are redacted in logs, Export and Explain. Vault values are always sensitive.
+//
 // [vault]: https://github.com/hashicorp/vault
 type Options struct {
-	Directory   string
-	DirFS       fs.ReadDirFS
-	Instance    string
-	Deployment  string
-	Hostname    string
-	Logger      *slog.Logger
-	VaultClient any
+	Directory     string
+	DirFS         fs.ReadDirFS
+	Instance      string
+	Deployment    string
+	Hostname      string
+	Logger        *slog.Logger
+	VaultClient   any
+	Watch         bool
+	WatchInterval time.Duration
+	OnReloadError func(error)
+	Schema        *schema.Schema
+	Sensitive     []string
+	Precedence    []source.SourceType
+	Tiers         []string
+
+	InstancePattern *regexp.Regexp
+	Dimensions      []source.Dimension
+	Layout          Layout
+
+	Strict           bool
+	KnownDeployments []string
+	KnownInstances   []string
+	DuplicatePolicy  DuplicatePolicy
+
+	Sources      []*source.Source
+	Constructors map[string]source.Constructor
 }
 
 type Config struct {
-	logger  *slog.Logger
-	sources []*source.Source
+	logger *slog.Logger
+	state  *state
+	prefix []string
 }
 
 // Creates new config instance. Provide Options object to set
@@ -101,11 +182,14 @@ func New(ctx context.Context, options Options) (cfg *Config, err error) {
 		}
 	}
 
+	deployments := splitDeployments(deployment)
+
 	if logger != nil {
-		logger.DebugContext(ctx, fmt.Sprintf("directory: %s, fsys: %t, hostname: %s, deployment: %s, instance: %s", directory, options.DirFS != nil, hostname, deployment, instance))
+		logger.DebugContext(ctx, fmt.Sprintf("directory: %s, fsys: %t, hostname: %s, deployments: %v, instance: %s, dimensions: %v", directory, options.DirFS != nil, hostname, deployments, instance, options.Dimensions))
 	}
 
 	var dirFs []fs.ReadDirFS
+	var roots []string
 
 	if options.DirFS == nil {
 		for _, dir := range strings.Split(directory, string(os.PathListSeparator)) {
@@ -113,6 +197,7 @@ func New(ctx context.Context, options Options) (cfg *Config, err error) {
 
 			if fs, ok := os.DirFS(dir).(fs.ReadDirFS); ok {
 				dirFs = append(dirFs, fs)
+				roots = append(roots, dir)
 			} else {
 				return nil, err
 			}
@@ -121,21 +206,207 @@ func New(ctx context.Context, options Options) (cfg *Config, err error) {
 		directory = "."
 	} else {
 		dirFs = append(dirFs, options.DirFS)
+		roots = append(roots, "")
+	}
+
+	precedence, err := resolvePrecedence(options.Precedence, options.Tiers)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, src := range options.Sources {
+		if src == nil || src.Originer == nil || !slices.Contains(options.Tiers, src.Tier) {
+			return nil, fmt.Errorf("%w: source must have originer and declared tier", ErrInvalidSource)
+		}
+	}
+
+	for tier, c := range options.Constructors {
+		if c == nil || !slices.Contains(options.Tiers, tier) {
+			return nil, fmt.Errorf("%w: constructor of undeclared tier %q", ErrInvalidSource, tier)
+		}
+	}
+
+	for i, d := range options.Dimensions {
+		if d.Name == "" || d.Value == "" || slices.IndexFunc(options.Dimensions, func(o source.Dimension) bool {
+			return o.Name == d.Name
+		}) != i {
+			return nil, fmt.Errorf("%w: dimension %q is empty or duplicated", ErrInvalidDimension, d.Name)
+		}
+
+		// filename grammar is "{name}@{dimension}={value}"
+		if strings.ContainsAny(d.Name, "@=") || strings.ContainsAny(d.Value, "@=") {
+			return nil, fmt.Errorf("%w: dimension %q=%q contains \"@\" or \"=\"", ErrInvalidDimension, d.Name, d.Value)
+		}
+	}
+
+	set := &settings{
+		directory:        directory,
+		dirFs:            dirFs,
+		roots:            roots,
+		hostname:         hostname,
+		deployments:      deployments,
+		instance:         instance,
+		vaultClient:      options.VaultClient,
+		schema:           options.Schema,
+		precedence:       precedence,
+		tiers:            options.Tiers,
+		dimensions:       options.Dimensions,
+		layout:           options.Layout,
+		strict:           options.Strict,
+		knownDeployments: options.KnownDeployments,
+		knownInstances:   options.KnownInstances,
+		duplicates:       options.DuplicatePolicy,
+		sources:          options.Sources,
+		constructors:     options.Constructors,
+		parser: source.Parser{
+			Hostname:        hostname,
+			InstancePattern: options.InstancePattern,
+		},
+	}
+
+	// current and known deployments and instances resolve ambiguous filenames
+	set.parser.Deployments = append(slices.Clip(deployments), options.KnownDeployments...)
+
+	if instance != "" {
+		set.parser.Instances = []string{instance}
+	}
+
+	set.parser.Instances = append(set.parser.Instances, options.KnownInstances...)
+
+	for _, pattern := range options.Sensitive {
+		set.sensitive = append(set.sensitive, datamap.SplitPath(pattern))
+	}
+
+	sources, err := load(ctx, set)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg = &Config{
+		logger: logger,
+		state: &state{
+			settings: set,
+			memory: &source.Source{
+				Originer: memory.NewMemorySource(),
+				Type:     source.MemSrc,
+			},
+		},
 	}
 
+	cfg.state.sources = cfg.state.layer(sources)
+
+	if options.Watch {
+		interval := options.WatchInterval
+		if interval <= 0 {
+			interval = defaultWatchInterval
+		}
+
+		if err := cfg.state.watch(ctx, interval, logger, options.OnReloadError); err != nil {
+			return nil, err
+		}
+	}
+
+	return cfg, nil
+}
+
+// resolved options required to (re)load sources
+type settings struct {
+	directory   string
+	dirFs       []fs.ReadDirFS
+	roots       []string
+	hostname    string
+	deployments []string
+	instance    string
+	vaultClient any
+	schema      *schema.Schema
+	sensitive   [][]string
+	precedence  []source.SourceType
+	tiers       []string
+	parser      source.Parser
+	dimensions  []source.Dimension
+	layout      Layout
+
+	strict           bool
+	knownDeployments []string
+	knownInstances   []string
+	duplicates       DuplicatePolicy
+	sources          []*source.Source
+	constructors     map[string]source.Constructor
+}
+
+// load, sort and filter sources of all directories, create originers and validate the result
+func load(ctx context.Context, set *settings) ([]*source.Source, error) {
+	logger, _ := goconfigLogger.LoggerFromContext(ctx)
 	var sources []*source.Source
+	roots := map[*source.Source]string{}
 
-	for _, fs := range dirFs {
-		dirSources, err := loadDir(ctx, fs, directory, hostname)
+	for i, fs := range set.dirFs {
+		dirSources, err := loadDir(ctx, fs, set.directory, set.parser)
 		if err != nil {
 			return nil, err
 		}
 
+		if set.layout != LayoutFiles {
+			layoutSources, err := loadLayoutDirs(ctx, fs, set.directory, set.parser, set.layout == LayoutNamespacedDirs)
+			if err != nil {
+				return nil, err
+			}
+
+			dirSources = append(dirSources, layoutSources...)
+		}
+
+		for _, src := range dirSources {
+			roots[src] = set.roots[i]
+		}
+
 		sources = append(sources, dirSources...)
 	}
 
-	sortSources(sources)
-	sources = filterSources(sources, hostname, deployment, instance)
+	assignTiers(sources, set.tiers)
+
+	// files of other deployments are expected unless the valid ones are declared
+	deployments := set.strict || len(set.knownDeployments) > 0
+
+	if files := unrecognized(sources, set, deployments); len(files) > 0 {
+		if set.strict {
+			return nil, fmt.Errorf("%w: %s", ErrUnrecognizedFile, strings.Join(files, ", "))
+		}
+
+		if logger != nil {
+			for _, f := range files {
+				logger.WarnContext(ctx, "unrecognized file "+f)
+			}
+		}
+	}
+
+	sources = filterFormats(sources, set.constructors)
+
+	// custom sources are copied, so their originers are kept on reload
+	for _, custom := range set.sources {
+		src := *custom
+		src.Type = source.TierType(slices.Index(set.tiers, src.Tier))
+		sources = append(sources, &src)
+	}
+
+	sortDimensions(sources, set.dimensions)
+	sortDeployments(sources, set.deployments)
+	sortSources(sources, set.precedence...)
+	sources = filterSources(sources, set.hostname, set.deployments, set.instance)
+	sources = filterDimensions(sources, set.dimensions)
+
+	if set.duplicates != DuplicateMerge {
+		if files := duplicates(sources, set.directory, roots); len(files) > 0 {
+			if set.duplicates == DuplicateFail {
+				return nil, fmt.Errorf("%w: %s", ErrDuplicateSource, strings.Join(files, ", "))
+			}
+
+			if logger != nil {
+				for _, f := range files {
+					logger.WarnContext(ctx, "duplicate sources "+f)
+				}
+			}
+		}
+	}
 
 	if len(sources) == 0 {
 		return nil, ErrEmptyDir
@@ -143,12 +414,17 @@ func New(ctx context.Context, options Options) (cfg *Config, err error) {
 
 	for i, src := range sources {
 		var org source.Originer
+		var err error
 
-		switch src.Type {
-		case source.EnvSrc:
+		switch {
+		case src.Originer != nil:
+			org = src.Originer
+		case set.constructors[src.Tier] != nil:
+			org, err = set.constructors[src.Tier](ctx, src.DirFs, src.FilePath)
+		case src.Type == source.EnvSrc:
 			org, err = env.NewEnvSource(ctx, src.DirFs, src.FilePath)
-		case source.VaultSrc:
-			org, err = vault.NewVaultSource(ctx, src.DirFs, src.FilePath, options.VaultClient)
+		case src.Type == source.VaultSrc:
+			org, err = vault.NewVaultSource(ctx, src.DirFs, src.FilePath, set.vaultClient)
 		default:
 			org, err = file.NewPlainFileSource(ctx, src.DirFs, src.FilePath)
 		}
@@ -157,6 +433,10 @@ func New(ctx context.Context, options Options) (cfg *Config, err error) {
 			return nil, err
 		}
 
+		if src.Namespace != "" {
+			org = source.Namespace(org, src.Namespace)
+		}
+
 		src.Originer = org
 
 		if logger != nil {
@@ -164,8 +444,21 @@ func New(ctx context.Context, options Options) (cfg *Config, err error) {
 		}
 	}
 
-	return &Config{
-		logger:  logger,
-		sources: sources,
-	}, nil
+	if set.schema != nil {
+		// incomplete configuration can not be validated
+		res := search(ctx, sources, []string{""}, "")
+		if res.err != nil {
+			return nil, res.err
+		}
+
+		if !res.ok {
+			return nil, fmt.Errorf("schema validation: %w", ErrNotFound)
+		}
+
+		if err := set.schema.Validate(res.v); err != nil {
+			return nil, err
+		}
+	}
+
+	return sources, nil
 }
@@ -0,0 +1,283 @@
+package config
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/boolka/goconfig/pkg/datamap"
+)
+
+var errUnsupported = errors.New("unsupported conversion")
+
+var (
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	durationType        = reflect.TypeFor[time.Duration]()
+	timeType            = reflect.TypeFor[time.Time]()
+	urlType             = reflect.TypeFor[url.URL]()
+)
+
+// convert configuration value v to type t. Values produced by the serializers
+// (normalized numbers, strings, bools, maps and slices) and strings from environment
+// variables are coerced to the requested kind where it is lossless. Durations,
+// times and urls are parsed, strings are split by comma into slices.
+func convert(v any, t reflect.Type) (reflect.Value, error) {
+	if v == nil {
+		switch t.Kind() {
+		case reflect.Interface, reflect.Pointer, reflect.Map, reflect.Slice:
+			return reflect.Zero(t), nil
+		}
+
+		return reflect.Value{}, errUnsupported
+	}
+
+	rv := reflect.ValueOf(v)
+
+	if rv.Type().AssignableTo(t) {
+		out := reflect.New(t).Elem()
+		out.Set(rv)
+
+		return out, nil
+	}
+
+	out := reflect.New(t).Elem()
+
+	switch t {
+	case durationType:
+		d, err := parseDuration(v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		out.SetInt(int64(d))
+
+		return out, nil
+	case timeType:
+		tm, err := parseTime(v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		out.Set(reflect.ValueOf(tm))
+
+		return out, nil
+	case urlType:
+		s, ok := v.(string)
+		if !ok {
+			return reflect.Value{}, errUnsupported
+		}
+
+		u, err := url.Parse(strings.TrimSpace(s))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		out.Set(reflect.ValueOf(*u))
+
+		return out, nil
+	}
+
+	if s, ok := v.(string); ok && reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		if err := out.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+			return reflect.Value{}, err
+		}
+
+		return out, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		switch v := v.(type) {
+		case bool:
+			out.SetBool(v)
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return reflect.Value{}, err
+			}
+
+			out.SetBool(b)
+		default:
+			return reflect.Value{}, errUnsupported
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i := rv.Int()
+			if out.OverflowInt(i) {
+				return reflect.Value{}, strconv.ErrRange
+			}
+
+			out.SetInt(i)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			u := rv.Uint()
+			if u > math.MaxInt64 || out.OverflowInt(int64(u)) {
+				return reflect.Value{}, strconv.ErrRange
+			}
+
+			out.SetInt(int64(u))
+		case reflect.Float32, reflect.Float64:
+			f := rv.Float()
+			if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 || out.OverflowInt(int64(f)) {
+				return reflect.Value{}, strconv.ErrRange
+			}
+
+			out.SetInt(int64(f))
+		case reflect.String:
+			i, err := strconv.ParseInt(strings.TrimSpace(rv.String()), 0, t.Bits())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+
+			out.SetInt(i)
+		default:
+			return reflect.Value{}, errUnsupported
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i := rv.Int()
+			if i < 0 || out.OverflowUint(uint64(i)) {
+				return reflect.Value{}, strconv.ErrRange
+			}
+
+			out.SetUint(uint64(i))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			u := rv.Uint()
+			if out.OverflowUint(u) {
+				return reflect.Value{}, strconv.ErrRange
+			}
+
+			out.SetUint(u)
+		case reflect.Float32, reflect.Float64:
+			f := rv.Float()
+			if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 || out.OverflowUint(uint64(f)) {
+				return reflect.Value{}, strconv.ErrRange
+			}
+
+			out.SetUint(uint64(f))
+		case reflect.String:
+			u, err := strconv.ParseUint(strings.TrimSpace(rv.String()), 0, t.Bits())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+
+			out.SetUint(u)
+		default:
+			return reflect.Value{}, errUnsupported
+		}
+	case reflect.Float32, reflect.Float64:
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			out.SetFloat(float64(rv.Int()))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			out.SetFloat(float64(rv.Uint()))
+		case reflect.Float32, reflect.Float64:
+			f := rv.Float()
+			if out.OverflowFloat(f) {
+				return reflect.Value{}, strconv.ErrRange
+			}
+
+			out.SetFloat(f)
+		case reflect.String:
+			f, err := strconv.ParseFloat(strings.TrimSpace(rv.String()), t.Bits())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+
+			out.SetFloat(f)
+		default:
+			return reflect.Value{}, errUnsupported
+		}
+	case reflect.String:
+		switch rv.Kind() {
+		case reflect.String:
+			out.SetString(rv.String())
+		case reflect.Bool,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			out.SetString(fmt.Sprint(v))
+		default:
+			return reflect.Value{}, errUnsupported
+		}
+	case reflect.Slice:
+		// comma separated list, for example from environment variable
+		if s, ok := v.(string); ok {
+			var items []any
+
+			for item := range strings.SplitSeq(s, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+
+			rv = reflect.ValueOf(items)
+		}
+
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return reflect.Value{}, errUnsupported
+		}
+
+		out.Set(reflect.MakeSlice(t, rv.Len(), rv.Len()))
+
+		for i := range rv.Len() {
+			elem, err := convert(rv.Index(i).Interface(), t.Elem())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("index %d: %w", i, err)
+			}
+
+			out.Index(i).Set(elem)
+		}
+	case reflect.Map:
+		if rv.Kind() != reflect.Map || t.Key().Kind() != reflect.String || rv.Type().Key().Kind() != reflect.String {
+			return reflect.Value{}, errUnsupported
+		}
+
+		out.Set(reflect.MakeMapWithSize(t, rv.Len()))
+
+		iter := rv.MapRange()
+		for iter.Next() {
+			elem, err := convert(iter.Value().Interface(), t.Elem())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("key %s: %w", iter.Key().String(), err)
+			}
+
+			out.SetMapIndex(iter.Key().Convert(t.Key()), elem)
+		}
+	case reflect.Struct:
+		m, ok := v.(map[string]any)
+		if !ok {
+			return reflect.Value{}, errUnsupported
+		}
+
+		var errs []error
+
+		bindStruct(out, "", "", func(path string) (any, bool) {
+			return datamap.GetByPath(m, path)
+		}, &errs)
+
+		if len(errs) > 0 {
+			return reflect.Value{}, errors.Join(errs...)
+		}
+	case reflect.Pointer:
+		elem, err := convert(v, t.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		ptr := reflect.New(t.Elem())
+		ptr.Elem().Set(elem)
+		out.Set(ptr)
+	default:
+		return reflect.Value{}, errUnsupported
+	}
+
+	return out, nil
+}
@@ -4,13 +4,15 @@ import (
 	"context"
 	"io/fs"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/boolka/goconfig/pkg/datamap"
+	"github.com/boolka/goconfig/pkg/file"
 	"github.com/boolka/goconfig/pkg/source"
 )
 
-func loadDir(ctx context.Context, dirFs fs.ReadDirFS, directory string, hostname string) ([]*source.Source, error) {
+func loadDir(ctx context.Context, dirFs fs.ReadDirFS, directory string, parser source.Parser) ([]*source.Source, error) {
 	var sources []*source.Source
 
 	dirEntries, err := fs.ReadDir(dirFs, directory)
@@ -29,7 +31,7 @@ func loadDir(ctx context.Context, dirFs fs.ReadDirFS, directory string, hostname
 		}
 		fPath := filepath.Join(directory, fName)
 
-		src, err := source.New(ctx, dirFs, fPath, hostname)
+		src, err := parser.New(ctx, dirFs, fPath)
 		if err != nil {
 			if err == datamap.ErrUnknownFileSource {
 				continue
@@ -43,3 +45,56 @@ func loadDir(ctx context.Context, dirFs fs.ReadDirFS, directory string, hostname
 
 	return sources, nil
 }
+
+// loadLayoutDirs loads files of subdirectories. Directory name is recognized like
+// configuration filename and applies to every file inside. If namespaced then keys
+// of the file are nested beneath its basename. Directories prefixed with "_" hold
+// included fragments and are skipped.
+func loadLayoutDirs(ctx context.Context, dirFs fs.ReadDirFS, directory string, parser source.Parser, namespaced bool) ([]*source.Source, error) {
+	var sources []*source.Source
+
+	dirEntries, err := fs.ReadDir(dirFs, directory)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dirEntry := range dirEntries {
+		dName := dirEntry.Name()
+		if !dirEntry.IsDir() || strings.HasPrefix(dName, ".") || strings.HasPrefix(dName, "_") {
+			continue
+		}
+
+		fileEntries, err := fs.ReadDir(dirFs, filepath.Join(directory, dName))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, fileEntry := range fileEntries {
+			fName := fileEntry.Name()
+			if fileEntry.IsDir() || strings.HasPrefix(fName, ".") {
+				continue
+			}
+
+			src, err := parser.NewNamed(ctx, dirFs, filepath.Join(directory, dName, fName), dName)
+			if err != nil {
+				return nil, err
+			}
+
+			if namespaced {
+				src.Namespace = file.FileName(fName)
+			}
+
+			sources = append(sources, src)
+		}
+	}
+
+	return sources, nil
+}
+
+// filterFormats retains files of registered formats (see datamap.RegisterFormat)
+// and files of tiers which originers are created by constructors
+func filterFormats(sources []*source.Source, constructors map[string]source.Constructor) []*source.Source {
+	return slices.DeleteFunc(sources, func(src *source.Source) bool {
+		return !datamap.HasFormat(filepath.Ext(src.FilePath)) && constructors[src.Tier] == nil
+	})
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/boolka/goconfig/pkg/source"
+)
+
+// DuplicatePolicy defines how sources of the same layer (identical type, tier, hostname,
+// deployment, instance and dimensions) are treated. For example "default.json" and
+// "default.yaml" or "production.toml" of two directories listed in GO_CONFIG_PATH.
+// Duplicates are ordered deterministically: the file of the earlier directory is the
+// higher, files of the same directory are ordered by name.
+type DuplicatePolicy int
+
+const (
+	// DuplicateMerge is the default. Duplicates are deep merged in the documented order.
+	DuplicateMerge DuplicatePolicy = iota
+	// DuplicateWarn is the same as DuplicateMerge except that duplicates are logged as warnings.
+	DuplicateWarn
+	// DuplicateFail makes New fail with ErrDuplicateSource.
+	DuplicateFail
+)
+
+// duplicates describes groups of sources of the same layer. Files of layout
+// subdirectories are parts of the layer by design and are not reported. Roots
+// are the configuration directories the sources were loaded from, custom sources
+// have no root and are not reported.
+func duplicates(sources []*source.Source, directory string, roots map[*source.Source]string) []string {
+	var keys []string
+	groups := map[string][]string{}
+
+	for _, src := range sources {
+		root, ok := roots[src]
+		if !ok || filepath.Dir(src.FilePath) != filepath.Clean(directory) {
+			continue
+		}
+
+		key := fmt.Sprintf("%d:%s:%s:%s:%s:%v", src.Type, src.Tier, src.Hostname, src.Deployment, src.Instance, src.Dimensions)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+
+		groups[key] = append(groups[key], filepath.Join(root, src.FilePath))
+	}
+
+	var files []string
+
+	for _, key := range keys {
+		if len(groups[key]) > 1 {
+			files = append(files, strings.Join(groups[key], " and "))
+		}
+	}
+
+	return files
+}
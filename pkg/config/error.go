@@ -1,5 +1,85 @@
 package config
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/boolka/goconfig/pkg/source"
+)
 
 var ErrEmptyDir = errors.New("empty directory")
+
+var ErrNotFound = errors.New("not found")
+
+var ErrTypeMismatch = errors.New("type mismatch")
+
+var ErrInterpolationCycle = errors.New("interpolation cycle")
+
+var ErrInvalidPrecedence = errors.New("invalid precedence")
+
+var ErrUnrecognizedFile = errors.New("unrecognized file")
+
+var ErrDuplicateSource = errors.New("duplicate source")
+
+var ErrInvalidSource = errors.New("invalid source")
+
+var ErrInvalidDimension = errors.New("invalid dimension")
+
+// TypeError describes a configuration value that can not be converted to the requested type.
+// File is the source file that held the value if known, it is empty for tables merged
+// from several sources. It matches ErrTypeMismatch with errors.Is.
+type TypeError struct {
+	Path  string
+	File  string
+	Value any
+	Type  reflect.Type
+	Err   error
+}
+
+func (e *TypeError) Error() string {
+	value := e.Value
+
+	// cause may quote the value, so it is omitted for secrets
+	secret, isSecret := value.(Secret)
+	if isSecret {
+		value = secret.Value()
+	}
+
+	msg := fmt.Sprintf("path %s: can not convert %v (%T) to %s", e.Path, e.Value, value, e.Type)
+
+	if e.File != "" {
+		msg = fmt.Sprintf("path %s of file %s: can not convert %v (%T) to %s", e.Path, e.File, e.Value, value, e.Type)
+	}
+
+	if e.Err != nil && !isSecret {
+		msg += ": " + e.Err.Error()
+	}
+
+	return msg
+}
+
+func (e *TypeError) Is(target error) bool {
+	return target == ErrTypeMismatch
+}
+
+func (e *TypeError) Unwrap() error {
+	return e.Err
+}
+
+// SourceError describes a failure of the source (unavailable vault server, broken
+// reference and etc.) while looking up the path. Type is the source type of the File.
+type SourceError struct {
+	File string
+	Type source.SourceType
+	Path string
+	Err  error
+}
+
+func (e *SourceError) Error() string {
+	return fmt.Sprintf("path %s of %s source %s: %s", e.Path, e.Type, e.File, e.Err)
+}
+
+func (e *SourceError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/boolka/goconfig/pkg/datamap"
+	goconfigLogger "github.com/boolka/goconfig/pkg/logger"
+	"github.com/boolka/goconfig/pkg/source"
+)
+
+// Origin describes a source that defines the explained path.
+//
+//   - Hostname: hostname of the host files ("{hostname}.EXT" and etc.), empty for other sources
+//
+//   - Tier: name of the custom tier if the source belongs to one (see Options.Tiers)
+//
+//   - Reference: environment variable name for env source or "mount,secret,key" location for vault source
+//
+//   - Resolved: states if the source returned value. Environment variable may be unset or vault may be unavailable
+//
+//   - Value: resolved value, sensitive values are wrapped into Secret
+//
+//   - Err: error returned by the source if any
+//
+//   - Winner: the value of this source is returned by Get. Tables of lower resolved sources are merged beneath it
+type Origin struct {
+	File       string
+	Type       source.SourceType
+	Hostname   string
+	Deployment string
+	Instance   string
+	Tier       string
+	Dimensions []source.Dimension
+	Reference  string
+	Resolved   bool
+	Value      any
+	Err        error
+	Winner     bool
+}
+
+// Explain returns every source that defines the path in lookup order.
+// Use it to find out which file supplied the value and what it shadowed.
+func (c *Config) Explain(ctx context.Context, path string) ([]Origin, error) {
+	if c.logger != nil {
+		ctx = goconfigLogger.ContextWithLogger(ctx, c.logger)
+		c.logger.DebugContext(ctx, fmt.Sprintf("explain %s field", path))
+	}
+
+	path = c.abs(path)
+	keys := datamap.SplitPath(path)
+
+	var origins []Origin
+	var won bool
+
+	for _, src := range c.state.snapshot() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		origin := Origin{
+			File:       src.FilePath,
+			Type:       src.Type,
+			Deployment: src.Deployment,
+			Instance:   src.Instance,
+			Tier:       src.Tier,
+			Dimensions: src.Dimensions,
+		}
+
+		switch src.Type {
+		case source.HostSrc, source.HostInstSrc, source.HostDepSrc, source.HostDepInstSrc:
+			origin.Hostname = src.Hostname
+		}
+
+		var defined bool
+
+		if r, ok := src.Originer.(source.Referencer); ok {
+			origin.Reference, defined = r.Reference(ctx, path)
+		}
+
+		v, ok := src.Get(ctx, path)
+		if ok {
+			defined = true
+			origin.Resolved = true
+			origin.Value = c.redactSource(ctx, src, keys, v, nil)
+
+			if !won {
+				won = true
+				origin.Winner = true
+			}
+		} else if err, isErr := v.(error); isErr {
+			defined = true
+			origin.Err = err
+		}
+
+		if defined {
+			origins = append(origins, origin)
+		}
+	}
+
+	return origins, nil
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	goconfigLogger "github.com/boolka/goconfig/pkg/logger"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is an export encoding
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+	FormatTOML Format = "toml"
+)
+
+var ErrUnknownFormat = errors.New("unknown format")
+
+// Export encodes the effective configuration tree into w. All the sources relevant
+// to the current hostname, deployment and instance are merged, environment variables
+// and vault secrets are resolved and references are interpolated. Sensitive values
+// are redacted. Failed sources are reported the same way as Lookup does.
+func (c *Config) Export(ctx context.Context, w io.Writer, format Format) error {
+	if c.logger != nil {
+		ctx = goconfigLogger.ContextWithLogger(ctx, c.logger)
+		c.logger.DebugContext(ctx, fmt.Sprintf("export configuration as %s", format))
+	}
+
+	var encode func(any) error
+
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		encode = enc.Encode
+	case FormatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		defer enc.Close()
+		encode = enc.Encode
+	case FormatTOML:
+		encode = toml.NewEncoder(w).Encode
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+
+	res, err := c.lookup(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	if err := encode(c.redact(ctx, c.prefix, res.v, res.refs)); err != nil {
+		return fmt.Errorf("encode %s error: %w", format, err)
+	}
+
+	return nil
+}
@@ -3,13 +3,19 @@ package config
 import (
 	"context"
 	"fmt"
+	"log/slog"
 
+	"github.com/boolka/goconfig/pkg/datamap"
 	goconfigLogger "github.com/boolka/goconfig/pkg/logger"
+	"github.com/boolka/goconfig/pkg/source"
 )
 
 type srcValue struct {
-	v  any
-	ok bool
+	v    any
+	src  *source.Source
+	ok   bool
+	err  error
+	refs []reference
 }
 
 // Get method takes dot delimited configuration path and returns value if any.
@@ -23,6 +29,15 @@ func (c *Config) Get(ctx context.Context, path string, files ...string) (any, bo
 		c.logger.DebugContext(ctx, fmt.Sprintf("get %s field with files %v", path, files))
 	}
 
+	res := c.get(ctx, path, files...)
+
+	c.logResult(ctx, path, res)
+
+	return res.v, res.ok
+}
+
+// search sources in separate goroutine to return as soon as context is done
+func (c *Config) get(ctx context.Context, path string, files ...string) srcValue {
 	done := make(chan srcValue, 1)
 
 	go func() {
@@ -33,26 +48,85 @@ func (c *Config) Get(ctx context.Context, path string, files ...string) (any, bo
 		defer func() {
 			if panicErr := recover(); panicErr != nil {
 				done <- srcValue{
-					v:  panicErr,
-					ok: false,
+					v:   panicErr,
+					ok:  false,
+					err: fmt.Errorf("path %s: panic: %v", path, panicErr),
 				}
 			}
 		}()
 
-		v, ok := searchSources(ctx, c.sources, path, files...)
+		path := c.abs(path)
 
-		done <- srcValue{
-			v:  v,
-			ok: ok,
-		}
+		done <- search(ctx, c.state.snapshot(), []string{normalizePath(path)}, path, files...)
 	}()
 
 	select {
 	case <-ctx.Done():
-		return ctx.Err(), false
+		return srcValue{
+			v:   ctx.Err(),
+			ok:  false,
+			err: ctx.Err(),
+		}
 	case res := <-done:
-		return res.v, res.ok
+		return res
+	}
+}
+
+// Lookup method is the same as Get except that it reports why the value is missing.
+// ErrNotFound is returned if no source has the path. If the value is missing because
+// of failed source (for example unavailable vault server) then the first failure is
+// returned as *SourceError. Failures of sources above the found value are only logged.
+func (c *Config) Lookup(ctx context.Context, path string, files ...string) (any, error) {
+	if c.logger != nil {
+		ctx = goconfigLogger.ContextWithLogger(ctx, c.logger)
+		c.logger.DebugContext(ctx, fmt.Sprintf("lookup %s field with files %v", path, files))
+	}
+
+	res, err := c.lookup(ctx, path, files...)
+
+	c.logResult(ctx, path, res)
+
+	if err != nil {
+		return nil, err
 	}
+
+	return res.v, nil
+}
+
+func (c *Config) lookup(ctx context.Context, path string, files ...string) (srcValue, error) {
+	res := c.get(ctx, path, files...)
+
+	if !res.ok {
+		if res.err != nil {
+			return res, res.err
+		}
+
+		return res, fmt.Errorf("path %s: %w", path, ErrNotFound)
+	}
+
+	return res, nil
+}
+
+// logResult logs found value with sensitive values redacted
+func (c *Config) logResult(ctx context.Context, path string, res srcValue) {
+	if c.logger == nil || !res.ok || !c.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	var file string
+	if res.src != nil {
+		file = res.src.FilePath
+	}
+
+	v := c.redactSource(ctx, res.src, datamap.SplitPath(c.abs(path)), res.v, res.refs)
+
+	c.logger.DebugContext(ctx, fmt.Sprintf("found %s field in %q source: %v", path, file, v))
+}
+
+// GetPath method is the same as Get except that it takes already split path keys.
+// Keys may contain dots, they are not treated as delimiters.
+func (c *Config) GetPath(ctx context.Context, keys []string, files ...string) (any, bool) {
+	return c.Get(ctx, datamap.JoinPath(keys), files...)
 }
 
 // MustGet method is the same as Get except that it panics if the path does not exist
@@ -69,7 +143,7 @@ func (c *Config) MustGet(ctx context.Context, path string, files ...string) any
 			return
 		}
 
-		v, ok := searchSources(ctx, c.sources, path, files...)
+		v, ok := searchSources(ctx, c.state.snapshot(), c.abs(path), files...)
 
 		done <- srcValue{
 			v:  v,
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/boolka/goconfig/pkg/datamap"
+	"github.com/boolka/goconfig/pkg/source"
+)
+
+// reference is the path interpolated into the value at keys (relative to the value root)
+type reference struct {
+	keys []string
+	path string
+}
+
+// interpolate expands ${path} references in string values of v. Tables and
+// arrays are copied. Visiting holds the paths being resolved to detect cycles.
+// Used references are appended to refs at keys of the expanded values, so the
+// values which embed sensitive ones are redacted as well.
+func interpolate(ctx context.Context, sources []*source.Source, v any, visiting []string, keys []string, refs *[]reference) (any, error) {
+	switch v := v.(type) {
+	case string:
+		return expand(ctx, sources, v, visiting, keys, refs)
+	case map[string]any:
+		m := make(map[string]any, len(v))
+
+		for k, e := range v {
+			x, err := interpolate(ctx, sources, e, visiting, append(slices.Clip(keys), k), refs)
+			if err != nil {
+				return nil, err
+			}
+
+			m[k] = x
+		}
+
+		return m, nil
+	case []any:
+		a := make([]any, len(v))
+
+		for i, e := range v {
+			x, err := interpolate(ctx, sources, e, visiting, append(slices.Clip(keys), strconv.Itoa(i)), refs)
+			if err != nil {
+				return nil, err
+			}
+
+			a[i] = x
+		}
+
+		return a, nil
+	}
+
+	return v, nil
+}
+
+// expand references of the string. "$${" is an escape sequence for literal "${".
+// If the whole string is a single reference then the referenced value keeps its type.
+func expand(ctx context.Context, sources []*source.Source, s string, visiting []string, keys []string, refs *[]reference) (any, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		switch {
+		case strings.HasPrefix(s[i:], "$${"):
+			b.WriteString("${")
+			i += 2
+		case strings.HasPrefix(s[i:], "${"):
+			end := strings.IndexByte(s[i+2:], '}')
+			if end < 0 {
+				b.WriteString(s[i:])
+				return b.String(), nil
+			}
+
+			ref := normalizePath(s[i+2 : i+2+end])
+
+			if slices.Contains(visiting, ref) {
+				return nil, fmt.Errorf("%w: %s", ErrInterpolationCycle, strings.Join(append(slices.Clip(visiting), ref), " -> "))
+			}
+
+			res := search(ctx, sources, append(slices.Clip(visiting), ref), ref)
+			if !res.ok {
+				if res.err != nil {
+					return nil, res.err
+				}
+
+				return nil, fmt.Errorf("reference ${%s}: %w", ref, ErrNotFound)
+			}
+
+			// references of the referenced value are collapsed to the keys
+			*refs = append(*refs, reference{keys: keys, path: ref})
+			for _, r := range res.refs {
+				*refs = append(*refs, reference{keys: keys, path: r.path})
+			}
+
+			if i == 0 && i+2+end+1 == len(s) {
+				return res.v, nil
+			}
+
+			b.WriteString(fmt.Sprint(res.v))
+			i += 2 + end
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+
+	return b.String(), nil
+}
+
+// bring differently quoted paths to the same form
+func normalizePath(path string) string {
+	return datamap.JoinPath(datamap.SplitPath(path))
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/boolka/goconfig/pkg/datamap"
+	goconfigLogger "github.com/boolka/goconfig/pkg/logger"
+	"github.com/boolka/goconfig/pkg/memory"
+	"github.com/boolka/goconfig/pkg/source"
+)
+
+var errSetPath = errors.New("path must be non empty and without wildcards and array elements")
+
+// Set overrides the value of path at runtime. Overrides are kept in memory source
+// which is placed above all the others (even vault) and survive reloads. Setting
+// a table overrides only its keys, the rest are merged from lower sources. Arrays are
+// overridden as a whole, paths of array elements are rejected. Subscribers
+// of changed paths are notified from the calling goroutine. It is safe for concurrent use.
+func (c *Config) Set(ctx context.Context, path string, value any) error {
+	_, err := c.Override(ctx, path, value)
+
+	return err
+}
+
+// Unset removes runtime override of path, so the value of the highest source
+// that has the path is visible again.
+func (c *Config) Unset(ctx context.Context, path string) {
+	if c.logger != nil {
+		ctx = goconfigLogger.ContextWithLogger(ctx, c.logger)
+		c.logger.DebugContext(ctx, fmt.Sprintf("unset %s field", path))
+	}
+
+	keys := datamap.SplitPath(c.abs(path))
+	if len(keys) == 0 || datamap.HasWildcard(keys) {
+		return
+	}
+
+	c.state.override(ctx, func(mem *memory.MemorySource) *memory.MemorySource {
+		return mem.Unset(keys)
+	})
+}
+
+// Override is scoped Set. Returned function restores the previous override of path
+// or removes it if there was none. It is handy in tests:
+//
+//	restore, err := cfg.Override(ctx, "feature.enabled", true)
+//	defer restore()
+func (c *Config) Override(ctx context.Context, path string, value any) (func(), error) {
+	if c.logger != nil {
+		ctx = goconfigLogger.ContextWithLogger(ctx, c.logger)
+		c.logger.DebugContext(ctx, fmt.Sprintf("set %s field", path))
+	}
+
+	keys := datamap.SplitPath(c.abs(path))
+	if len(keys) == 0 || datamap.HasWildcard(keys) {
+		return nil, fmt.Errorf("set %s: %w", path, errSetPath)
+	}
+
+	// arrays are overridden as a whole, otherwise the array would be shadowed by the table of elements
+	sources := c.state.snapshot()
+	for i := 1; i < len(keys); i++ {
+		if v, ok := searchSources(ctx, sources, datamap.JoinPath(keys[:i])); ok {
+			if _, isArray := v.([]any); isArray {
+				return nil, fmt.Errorf("set %s: %w", path, errSetPath)
+			}
+		}
+	}
+
+	var prev any
+	var hadPrev bool
+
+	c.state.override(ctx, func(mem *memory.MemorySource) *memory.MemorySource {
+		prev, hadPrev = mem.Get(ctx, datamap.JoinPath(keys))
+
+		return mem.Set(keys, value)
+	})
+
+	// the context of the call may be done when restoring, its values (logger) are kept
+	restoreCtx := context.WithoutCancel(ctx)
+
+	return func() {
+		c.state.override(restoreCtx, func(mem *memory.MemorySource) *memory.MemorySource {
+			if hadPrev {
+				return mem.Set(keys, prev)
+			}
+
+			return mem.Unset(keys)
+		})
+	}, nil
+}
+
+// override replaces memory source with the modified one and notifies subscribers
+func (s *state) override(ctx context.Context, modify func(*memory.MemorySource) *memory.MemorySource) {
+	s.mu.Lock()
+	old := s.sources
+	s.memory = &source.Source{
+		Originer: modify(s.memory.Originer.(*memory.MemorySource)),
+		Type:     source.MemSrc,
+	}
+	s.sources = s.layer(old[1:])
+	sources := s.sources
+	subs := s.subscriptions()
+	s.mu.Unlock()
+
+	notify(ctx, subs, old, sources)
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/boolka/goconfig/pkg/source"
+)
+
+// reserved file names can not be used as tier names
+var reservedNames = []string{"env", "vault", "default", "local"}
+
+// defaultPrecedence returns built in order of source types from the highest to
+// the lowest. Tiers are placed beneath environment in order of declaration.
+func defaultPrecedence(tiers int) []source.SourceType {
+	precedence := []source.SourceType{source.VaultSrc, source.EnvSrc}
+
+	for i := range tiers {
+		precedence = append(precedence, source.TierType(i))
+	}
+
+	for t := source.LocDepInstSrc; t >= source.DefSrc; t-- {
+		precedence = append(precedence, t)
+	}
+
+	return precedence
+}
+
+// resolvePrecedence validates custom precedence and tier names. Custom precedence
+// must list every built in file source type and every tier exactly once.
+func resolvePrecedence(custom []source.SourceType, tiers []string) ([]source.SourceType, error) {
+	for i, name := range tiers {
+		if name == "" || slices.Contains(reservedNames, name) || slices.Index(tiers, name) != i {
+			return nil, fmt.Errorf("%w: tier name %q is empty, reserved or duplicated", ErrInvalidPrecedence, name)
+		}
+	}
+
+	def := defaultPrecedence(len(tiers))
+
+	if custom == nil {
+		return def, nil
+	}
+
+	for i, t := range custom {
+		if !slices.Contains(def, t) {
+			return nil, fmt.Errorf("%w: unexpected %s source type (%d)", ErrInvalidPrecedence, t, t)
+		}
+
+		if slices.Index(custom, t) != i {
+			return nil, fmt.Errorf("%w: duplicated %s source type (%d)", ErrInvalidPrecedence, t, t)
+		}
+	}
+
+	for _, t := range def {
+		if !slices.Contains(custom, t) {
+			return nil, fmt.Errorf("%w: missing %s source type (%d)", ErrInvalidPrecedence, t, t)
+		}
+	}
+
+	return slices.Clone(custom), nil
+}
+
+// assignTiers marks sources of tier files
+func assignTiers(sources []*source.Source, tiers []string) {
+	for _, src := range sources {
+		if i := slices.Index(tiers, src.Name); i >= 0 {
+			src.Type = source.TierType(i)
+			src.Tier = tiers[i]
+			src.Deployment = ""
+			src.Instance = ""
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+
+	"github.com/boolka/goconfig/pkg/datamap"
+	"github.com/boolka/goconfig/pkg/source"
+)
+
+const redacted = "***"
+
+// Secret wraps sensitive value so it is rendered as "***" when printed,
+// logged or encoded. Use Value to get the wrapped value.
+type Secret struct {
+	value any
+}
+
+func (s Secret) Value() any {
+	return s.value
+}
+
+func (s Secret) String() string {
+	return redacted
+}
+
+func (s Secret) GoString() string {
+	return redacted
+}
+
+// Format renders "***" for any verb
+func (s Secret) Format(f fmt.State, _ rune) {
+	fmt.Fprint(f, redacted)
+}
+
+func (s Secret) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
+
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(redacted)), nil
+}
+
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(redacted), nil
+}
+
+func (s Secret) MarshalYAML() (any, error) {
+	return redacted, nil
+}
+
+// sensitive reports if the value of path must be redacted. Paths matched by
+// the Sensitive option patterns (and everything beneath) and paths defined by
+// vault sources are sensitive.
+func (c *Config) sensitive(ctx context.Context, keys []string) bool {
+	for _, pattern := range c.state.settings.sensitive {
+		if matchPrefix(pattern, keys) {
+			return true
+		}
+	}
+
+	path := datamap.JoinPath(keys)
+
+	for _, src := range c.state.snapshot() {
+		if src.Type != source.VaultSrc {
+			continue
+		}
+
+		if r, ok := src.Originer.(source.Referencer); ok {
+			if _, ok := r.Reference(ctx, path); ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// matchPrefix reports if keys start with pattern, wildcard matches any key
+func matchPrefix(pattern, keys []string) bool {
+	if len(pattern) > len(keys) {
+		return false
+	}
+
+	for i, p := range pattern {
+		if p != datamap.Wildcard && p != keys[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// redactSource is redact which treats values of vault sources as sensitive as a whole
+func (c *Config) redactSource(ctx context.Context, src *source.Source, keys []string, v any, refs []reference) any {
+	if src != nil && src.Type == source.VaultSrc {
+		return Secret{
+			value: v,
+		}
+	}
+
+	return c.redact(ctx, keys, v, refs)
+}
+
+// redact wraps sensitive values of v found by keys into Secret. Values which
+// interpolate sensitive paths (refs are relative to v) are sensitive too. Tables and arrays are copied.
+func (c *Config) redact(ctx context.Context, keys []string, v any, refs []reference) any {
+	if c.sensitive(ctx, keys) || slices.ContainsFunc(refs, func(r reference) bool {
+		return len(r.keys) == 0 && c.sensitive(ctx, datamap.SplitPath(r.path))
+	}) {
+		return Secret{
+			value: v,
+		}
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+
+		for k, e := range v {
+			m[k] = c.redact(ctx, append(slices.Clip(keys), k), e, descend(refs, k))
+		}
+
+		return m
+	case []any:
+		a := make([]any, len(v))
+
+		for i, e := range v {
+			a[i] = c.redact(ctx, append(slices.Clip(keys), strconv.Itoa(i)), e, descend(refs, strconv.Itoa(i)))
+		}
+
+		return a
+	}
+
+	return v
+}
+
+// descend returns references of the key of the value. Referenced table is
+// embedded as is, so its keys are the keys of the referenced path.
+func descend(refs []reference, key string) []reference {
+	var sub []reference
+
+	for _, r := range refs {
+		switch {
+		case len(r.keys) == 0:
+			sub = append(sub, reference{path: datamap.JoinPath(append(datamap.SplitPath(r.path), key))})
+		case r.keys[0] == key:
+			sub = append(sub, reference{keys: r.keys[1:], path: r.path})
+		}
+	}
+
+	return sub
+}
@@ -3,27 +3,101 @@ package config
 import (
 	"context"
 	"slices"
+	"strings"
 
+	"github.com/boolka/goconfig/pkg/datamap"
 	"github.com/boolka/goconfig/pkg/file"
 	goconfigLogger "github.com/boolka/goconfig/pkg/logger"
 	"github.com/boolka/goconfig/pkg/source"
 )
 
-func sortSources(sources []*source.Source) {
-	slices.SortFunc(sources, func(a, b *source.Source) int {
-		return int(b.Type) - int(a.Type)
+// sortSources orders sources from the highest to the lowest. Precedence lists
+// source types from the highest, if omitted the source types order is used.
+// Sorting is stable, so sources of the same type keep their order.
+func sortSources(sources []*source.Source, precedence ...source.SourceType) {
+	if len(precedence) == 0 {
+		slices.SortStableFunc(sources, func(a, b *source.Source) int {
+			return int(b.Type) - int(a.Type)
+		})
+
+		return
+	}
+
+	rank := make(map[source.SourceType]int, len(precedence))
+	for i, t := range precedence {
+		rank[t] = i
+	}
+
+	slices.SortStableFunc(sources, func(a, b *source.Source) int {
+		return rank[a.Type] - rank[b.Type]
+	})
+}
+
+// sortDeployments orders sources by deployment, the later deployment of the list
+// is the higher. Sources without deployment keep their order beneath.
+func sortDeployments(sources []*source.Source, deployments []string) {
+	slices.SortStableFunc(sources, func(a, b *source.Source) int {
+		return slices.Index(deployments, b.Deployment) - slices.Index(deployments, a.Deployment)
+	})
+}
+
+// sortDimensions orders sources by dimension qualifiers. Qualifier of the later
+// dimension outweighs all the earlier ones, unqualified sources are the lowest.
+func sortDimensions(sources []*source.Source, dims []source.Dimension) {
+	rank := func(src *source.Source) int {
+		var r int
+
+		for _, q := range src.Dimensions {
+			if i := slices.IndexFunc(dims, func(d source.Dimension) bool {
+				return d.Name == q.Name
+			}); i >= 0 {
+				r |= 1 << i
+			}
+		}
+
+		return r
+	}
+
+	slices.SortStableFunc(sources, func(a, b *source.Source) int {
+		return rank(b) - rank(a)
+	})
+}
+
+// filterDimensions retains sources which qualifiers all match current dimensions
+func filterDimensions(sources []*source.Source, dims []source.Dimension) []*source.Source {
+	return slices.DeleteFunc(sources, func(o *source.Source) bool {
+		for _, q := range o.Dimensions {
+			if !slices.Contains(dims, q) {
+				return true
+			}
+		}
+
+		return false
 	})
 }
 
+// splitDeployments parses comma delimited list of deployments
+func splitDeployments(deployment string) []string {
+	var deployments []string
+
+	for _, d := range strings.Split(deployment, ",") {
+		if d = strings.TrimSpace(d); d != "" && !slices.Contains(deployments, d) {
+			deployments = append(deployments, d)
+		}
+	}
+
+	return deployments
+}
+
 // retain only relevant to current environment sources
-func filterSources(sources []*source.Source, hostname, deployment, instance string) []*source.Source {
+func filterSources(sources []*source.Source, hostname string, deployments []string, instance string) []*source.Source {
 	return slices.DeleteFunc(sources, func(o *source.Source) bool {
 		if o.Type == source.EnvSrc || o.Type == source.VaultSrc || o.Type == source.DefSrc || o.Type == source.LocSrc {
 			return false
 		}
 
 		if (o.Hostname == "" || o.Hostname == hostname) &&
-			(o.Deployment == "" || o.Deployment == deployment) &&
+			(o.Deployment == "" || slices.Contains(deployments, o.Deployment)) &&
 			(o.Instance == "" || o.Instance == instance) {
 			return false
 		}
@@ -32,9 +106,54 @@ func filterSources(sources []*source.Source, hostname, deployment, instance stri
 	})
 }
 
+// searchSources returns value of the first source that has the path. If the value
+// is a table then tables of all the following sources are deep merged beneath it.
+// References to other paths inside string values are interpolated.
 func searchSources(ctx context.Context, sources []*source.Source, path string, files ...string) (any, bool) {
-	var v any
-	var ok bool
+	res := search(ctx, sources, []string{normalizePath(path)}, path, files...)
+
+	return res.v, res.ok
+}
+
+// search is searchSources with the stack of paths being interpolated. It also
+// returns the source which value was found (the highest one for merged tables)
+// and the first source failure. If nothing is found then the value is the failure itself.
+// References interpolated into the value are returned too, see interpolate.
+func search(ctx context.Context, sources []*source.Source, visiting []string, path string, files ...string) srcValue {
+	var merged map[string]any
+	var found *source.Source
+	var failure *SourceError
+	var refs []reference
+
+	fail := func(src *source.Source, err error) {
+		if logger, ok := goconfigLogger.LoggerFromContext(ctx); ok {
+			logger.InfoContext(ctx, err.Error())
+		}
+
+		if failure == nil {
+			failure = &SourceError{
+				File: src.FilePath,
+				Type: src.Type,
+				Path: path,
+				Err:  err,
+			}
+		}
+	}
+
+	result := func(v any, src *source.Source, ok bool) srcValue {
+		res := srcValue{
+			v:    v,
+			src:  src,
+			ok:   ok,
+			refs: refs,
+		}
+
+		if failure != nil {
+			res.err = failure
+		}
+
+		return res
+	}
 
 	for _, src := range sources {
 		if len(files) > 0 && !slices.ContainsFunc(files, func(fp string) bool {
@@ -43,18 +162,54 @@ func searchSources(ctx context.Context, sources []*source.Source, path string, f
 			continue
 		}
 
-		v, ok = src.Get(ctx, path)
-		if ok {
-			break
+		srcV, srcOk := src.Get(ctx, path)
+		if !srcOk {
+			if err, isErr := srcV.(error); isErr {
+				fail(src, err)
+			}
+
+			continue
 		}
 
-		if logger, ok := goconfigLogger.LoggerFromContext(ctx); ok {
-			switch v := v.(type) {
-			case error:
-				logger.InfoContext(ctx, v.Error())
+		m, isMap := srcV.(map[string]any)
+
+		if merged != nil && !isMap {
+			// scalar beneath the table is shadowed
+			continue
+		}
+
+		// values of external storages (environment, vault) are taken as is
+		if _, external := src.Originer.(source.Referencer); !external {
+			var err error
+
+			srcV, err = interpolate(ctx, sources, srcV, visiting, nil, &refs)
+			if err != nil {
+				fail(src, err)
+
+				return result(err, src, false)
 			}
+
+			m, isMap = srcV.(map[string]any)
+		}
+
+		switch {
+		case merged != nil:
+			merged = datamap.Merge(merged, m)
+		case isMap:
+			merged = datamap.Copy(m)
+			found = src
+		default:
+			return result(srcV, src, true)
 		}
 	}
 
-	return v, ok
+	if merged != nil {
+		return result(merged, found, true)
+	}
+
+	if failure != nil {
+		return result(failure.Err, nil, false)
+	}
+
+	return result(nil, nil, false)
 }
@@ -16,7 +16,7 @@ func TestConfigSources(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("sort", func(t *testing.T) {
-		sources, err := loadDir(ctx, os.DirFS("testdata").(fs.ReadDirFS), "config", "host-name")
+		sources, err := loadDir(ctx, os.DirFS("testdata").(fs.ReadDirFS), "config", source.Parser{Hostname: "host-name"})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -38,13 +38,13 @@ func TestConfigSources(t *testing.T) {
 	})
 
 	t.Run("load by hostname", func(t *testing.T) {
-		sources, err := loadDir(ctx, os.DirFS("testdata").(fs.ReadDirFS), "config", "host-name")
+		sources, err := loadDir(ctx, os.DirFS("testdata").(fs.ReadDirFS), "config", source.Parser{Hostname: "host-name"})
 		if err != nil {
 			t.Fatal(err)
 		}
 
 		sortSources(sources)
-		sources = filterSources(sources, "host-name", "", "")
+		sources = filterSources(sources, "host-name", nil, "")
 
 		if len(sources) != 5 {
 			for i, src := range sources {
@@ -55,13 +55,13 @@ func TestConfigSources(t *testing.T) {
 	})
 
 	t.Run("load by deployment", func(t *testing.T) {
-		sources, err := loadDir(ctx, os.DirFS("testdata").(fs.ReadDirFS), "config", "")
+		sources, err := loadDir(ctx, os.DirFS("testdata").(fs.ReadDirFS), "config", source.Parser{})
 		if err != nil {
 			t.Fatal(err)
 		}
 
 		sortSources(sources)
-		sources = filterSources(sources, "", "testing", "")
+		sources = filterSources(sources, "", []string{"testing"}, "")
 
 		if len(sources) != 6 {
 			for i, src := range sources {
@@ -72,13 +72,13 @@ func TestConfigSources(t *testing.T) {
 	})
 
 	t.Run("load by instance", func(t *testing.T) {
-		sources, err := loadDir(ctx, os.DirFS("testdata").(fs.ReadDirFS), "config", "")
+		sources, err := loadDir(ctx, os.DirFS("testdata").(fs.ReadDirFS), "config", source.Parser{})
 		if err != nil {
 			t.Fatal(err)
 		}
 
 		sortSources(sources)
-		sources = filterSources(sources, "", "", "1")
+		sources = filterSources(sources, "", nil, "1")
 
 		if len(sources) != 6 {
 			for i, src := range sources {
@@ -89,13 +89,13 @@ func TestConfigSources(t *testing.T) {
 	})
 
 	t.Run("load by hostname & instance", func(t *testing.T) {
-		sources, err := loadDir(ctx, os.DirFS("testdata").(fs.ReadDirFS), "config", "host-name")
+		sources, err := loadDir(ctx, os.DirFS("testdata").(fs.ReadDirFS), "config", source.Parser{Hostname: "host-name"})
 		if err != nil {
 			t.Fatal(err)
 		}
 
 		sortSources(sources)
-		sources = filterSources(sources, "host-name", "", "1")
+		sources = filterSources(sources, "host-name", nil, "1")
 
 		if len(sources) != 8 {
 			for i, src := range sources {
@@ -106,13 +106,13 @@ func TestConfigSources(t *testing.T) {
 	})
 
 	t.Run("load by hostname & deployment", func(t *testing.T) {
-		sources, err := loadDir(ctx, os.DirFS("testdata").(fs.ReadDirFS), "config", "host-name")
+		sources, err := loadDir(ctx, os.DirFS("testdata").(fs.ReadDirFS), "config", source.Parser{Hostname: "host-name"})
 		if err != nil {
 			t.Fatal(err)
 		}
 
 		sortSources(sources)
-		sources = filterSources(sources, "host-name", "testing", "")
+		sources = filterSources(sources, "host-name", []string{"testing"}, "")
 
 		if len(sources) != 8 {
 			for i, src := range sources {
@@ -123,13 +123,13 @@ func TestConfigSources(t *testing.T) {
 	})
 
 	t.Run("load by deployment & instance", func(t *testing.T) {
-		sources, err := loadDir(ctx, os.DirFS("testdata").(fs.ReadDirFS), "config", "")
+		sources, err := loadDir(ctx, os.DirFS("testdata").(fs.ReadDirFS), "config", source.Parser{})
 		if err != nil {
 			t.Fatal(err)
 		}
 
 		sortSources(sources)
-		sources = filterSources(sources, "", "testing", "1")
+		sources = filterSources(sources, "", []string{"testing"}, "1")
 
 		if len(sources) != 10 {
 			for i, src := range sources {
@@ -140,13 +140,13 @@ func TestConfigSources(t *testing.T) {
 	})
 
 	t.Run("load by hostname & deployment & instance", func(t *testing.T) {
-		sources, err := loadDir(ctx, os.DirFS("testdata").(fs.ReadDirFS), "config", "host-name")
+		sources, err := loadDir(ctx, os.DirFS("testdata").(fs.ReadDirFS), "config", source.Parser{Hostname: "host-name"})
 		if err != nil {
 			t.Fatal(err)
 		}
 
 		sortSources(sources)
-		sources = filterSources(sources, "host-name", "testing", "1")
+		sources = filterSources(sources, "host-name", []string{"testing"}, "1")
 
 		if len(sources) != 14 {
 			for i, src := range sources {
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"sync"
+
+	"github.com/boolka/goconfig/pkg/source"
+)
+
+// state is shared by config instance and its watcher
+type state struct {
+	mu       sync.RWMutex
+	settings *settings
+	sources  []*source.Source
+	memory   *source.Source
+
+	// watch state
+	fingerprint string
+	failed      string
+	subs        map[int]*subscription
+	nextSub     int
+	stop        func()
+	done        chan struct{}
+}
+
+// snapshot returns currently loaded sources. Reload replaces the slice, so it is safe to iterate over it without lock.
+func (s *state) snapshot() []*source.Source {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.sources
+}
+
+// layer puts memory source above loaded ones
+func (s *state) layer(sources []*source.Source) []*source.Source {
+	return append([]*source.Source{s.memory}, sources...)
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/boolka/goconfig/pkg/datamap"
+	"github.com/boolka/goconfig/pkg/source"
+)
+
+// unrecognized describes files of unregistered formats and files which names do not match
+// current or known deployments, instances and dimensions. Usually such files are mistyped
+// and silently ignored otherwise. Files of other deployments are reported only when
+// deployments is set, since they are expected unless the valid ones are declared.
+func unrecognized(sources []*source.Source, set *settings, deployments bool) []string {
+	var files []string
+
+	for _, src := range sources {
+		var reason string
+
+		switch {
+		case !datamap.HasFormat(filepath.Ext(src.FilePath)) && set.constructors[src.Tier] == nil:
+			reason = fmt.Sprintf("unknown format %q", filepath.Ext(src.FilePath))
+		case deployments && src.Deployment != "" && !slices.Contains(set.deployments, src.Deployment) && !slices.Contains(set.knownDeployments, src.Deployment):
+			reason = fmt.Sprintf("unknown deployment %q", src.Deployment)
+		case src.Instance != "" && src.Instance != set.instance && len(set.knownInstances) > 0 && !slices.Contains(set.knownInstances, src.Instance):
+			reason = fmt.Sprintf("unknown instance %q", src.Instance)
+		default:
+			for _, d := range src.Dimensions {
+				if !slices.ContainsFunc(set.dimensions, func(o source.Dimension) bool {
+					return o.Name == d.Name
+				}) {
+					reason = fmt.Sprintf("unknown dimension %q", d.Name)
+					break
+				}
+			}
+		}
+
+		if reason != "" {
+			files = append(files, fmt.Sprintf("%s (%s)", src.FilePath, reason))
+		}
+	}
+
+	return files
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"slices"
+
+	"github.com/boolka/goconfig/pkg/datamap"
+)
+
+// Sub returns a view of the configuration section at prefix. All lookups of the
+// view (Get, Lookup, typed getters, Bind, Explain, OnChange and etc.) take paths
+// relative to prefix while the full sources precedence including environment and
+// vault is still respected. References inside values stay absolute. The view
+// shares the state of the parent, so it follows reloads and Close of any of them
+// stops watching for all.
+func (c *Config) Sub(prefix string) *Config {
+	return &Config{
+		logger: c.logger,
+		state:  c.state,
+		prefix: append(slices.Clip(c.prefix), datamap.SplitPath(prefix)...),
+	}
+}
+
+// Prefix returns the path of the view section, it is empty for the root configuration
+func (c *Config) Prefix() string {
+	return datamap.JoinPath(c.prefix)
+}
+
+// abs returns the path prefixed with the view section
+func (c *Config) abs(path string) string {
+	if len(c.prefix) == 0 {
+		return path
+	}
+
+	return datamap.JoinPath(append(slices.Clip(c.prefix), datamap.SplitPath(path)...))
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"time"
+
+	"github.com/boolka/goconfig/pkg/datamap"
+	goconfigLogger "github.com/boolka/goconfig/pkg/logger"
+)
+
+// GetAs looks up the path like Get does and converts the value to type T.
+// Numbers are converted between integer, unsigned and float kinds if it is lossless,
+// strings (for example from environment variables) are parsed into numbers and bools.
+// Lookup errors are returned as is and *TypeError if the value can not be converted.
+func GetAs[T any](ctx context.Context, c *Config, path string, files ...string) (T, error) {
+	var zero T
+
+	if c.logger != nil {
+		ctx = goconfigLogger.ContextWithLogger(ctx, c.logger)
+		c.logger.DebugContext(ctx, fmt.Sprintf("get %s field as %s with files %v", path, reflect.TypeFor[T](), files))
+	}
+
+	res, err := c.lookup(ctx, path, files...)
+	if err != nil {
+		return zero, err
+	}
+
+	v, t := res.v, reflect.TypeFor[T]()
+
+	rv, err := convert(v, t)
+	if err != nil {
+		if err == errUnsupported {
+			err = nil
+		}
+
+		return zero, &TypeError{
+			Path:  path,
+			File:  sourceFile(res),
+			Value: c.redactSource(ctx, res.src, datamap.SplitPath(c.abs(path)), v, res.refs),
+			Type:  t,
+			Err:   err,
+		}
+	}
+
+	typed, _ := rv.Interface().(T)
+
+	return typed, nil
+}
+
+// sourceFile returns the file which held the value. Tables are merged from
+// several sources, so the file is unknown for them.
+func sourceFile(res srcValue) string {
+	if _, merged := res.v.(map[string]any); merged || res.src == nil {
+		return ""
+	}
+
+	return res.src.FilePath
+}
+
+// GetAsOr is the same as GetAs except that it returns def if the path does not exist.
+// Conversion errors are still returned.
+func GetAsOr[T any](ctx context.Context, c *Config, path string, def T, files ...string) (T, error) {
+	v, err := GetAs[T](ctx, c, path, files...)
+	if errors.Is(err, ErrNotFound) {
+		return def, nil
+	}
+
+	return v, err
+}
+
+// GetDuration returns duration parsed from string like "1m30s"
+func (c *Config) GetDuration(ctx context.Context, path string, files ...string) (time.Duration, error) {
+	return GetAs[time.Duration](ctx, c, path, files...)
+}
+
+// GetBytes returns number of bytes parsed from number or string with unit like "512MiB" (see ByteSize)
+func (c *Config) GetBytes(ctx context.Context, path string, files ...string) (uint64, error) {
+	b, err := GetAs[ByteSize](ctx, c, path, files...)
+
+	return uint64(b), err
+}
+
+// GetTime returns time from native date-time value or RFC3339 string.
+// Date-time and date without offset are considered to be in local time zone.
+func (c *Config) GetTime(ctx context.Context, path string, files ...string) (time.Time, error) {
+	return GetAs[time.Time](ctx, c, path, files...)
+}
+
+// GetURL returns parsed url
+func (c *Config) GetURL(ctx context.Context, path string, files ...string) (*url.URL, error) {
+	return GetAs[*url.URL](ctx, c, path, files...)
+}
+
+// GetString returns string, numbers and bools are formatted
+func (c *Config) GetString(ctx context.Context, path string, files ...string) (string, error) {
+	return GetAs[string](ctx, c, path, files...)
+}
+
+// GetStringSlice returns array elements as strings. String value is split by comma.
+func (c *Config) GetStringSlice(ctx context.Context, path string, files ...string) ([]string, error) {
+	return GetAs[[]string](ctx, c, path, files...)
+}
+
+// GetStringMap returns table
+func (c *Config) GetStringMap(ctx context.Context, path string, files ...string) (map[string]any, error) {
+	return GetAs[map[string]any](ctx, c, path, files...)
+}
+
+// GetStringMapString returns table values as strings
+func (c *Config) GetStringMapString(ctx context.Context, path string, files ...string) (map[string]string, error) {
+	return GetAs[map[string]string](ctx, c, path, files...)
+}
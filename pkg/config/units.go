@@ -0,0 +1,122 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// ByteSize is a number of bytes. It is decoded from plain numbers and from strings
+// with decimal (KB, MB, GB, TB, PB) or binary (KiB, MiB, GiB, TiB, PiB) units.
+// Units are case insensitive, "K", "M", "G", "T" and "P" are decimal too.
+type ByteSize uint64
+
+var byteUnits = map[string]uint64{
+	"":    1,
+	"b":   1,
+	"k":   1e3,
+	"kb":  1e3,
+	"kib": 1 << 10,
+	"m":   1e6,
+	"mb":  1e6,
+	"mib": 1 << 20,
+	"g":   1e9,
+	"gb":  1e9,
+	"gib": 1 << 30,
+	"t":   1e12,
+	"tb":  1e12,
+	"tib": 1 << 40,
+	"p":   1e15,
+	"pb":  1e15,
+	"pib": 1 << 50,
+}
+
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(s)
+	}
+
+	number, unit := s[:i], strings.ToLower(strings.TrimSpace(s[i:]))
+
+	multiplier, ok := byteUnits[unit]
+	if !ok || number == "" {
+		return fmt.Errorf("invalid byte size %q", s)
+	}
+
+	if n, err := strconv.ParseUint(number, 10, 64); err == nil {
+		if n > math.MaxUint64/multiplier {
+			return fmt.Errorf("byte size %q: %w", s, strconv.ErrRange)
+		}
+
+		*b = ByteSize(n * multiplier)
+
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return fmt.Errorf("invalid byte size %q", s)
+	}
+
+	f *= float64(multiplier)
+	if f >= math.MaxUint64 {
+		return fmt.Errorf("byte size %q: %w", s, strconv.ErrRange)
+	}
+
+	*b = ByteSize(f)
+
+	return nil
+}
+
+var errDurationUnit = errors.New(`duration must be a string with unit, for example "30s"`)
+
+func parseDuration(v any) (time.Duration, error) {
+	s, ok := v.(string)
+	if !ok {
+		return 0, errDurationUnit
+	}
+
+	return time.ParseDuration(strings.TrimSpace(s))
+}
+
+// layouts of time strings, the ones without offset are parsed in local time zone
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	time.DateOnly,
+}
+
+// parseTime accepts strings and toml local date and date-time values. Values
+// without offset are considered to be in local time zone.
+func parseTime(v any) (time.Time, error) {
+	switch v := v.(type) {
+	case toml.LocalDateTime:
+		return v.AsTime(time.Local), nil
+	case toml.LocalDate:
+		return v.AsTime(time.Local), nil
+	case string:
+		s := strings.TrimSpace(v)
+
+		for _, layout := range timeLayouts {
+			if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+				return t, nil
+			}
+		}
+
+		return time.Time{}, fmt.Errorf("invalid time %q, expected RFC3339 date-time or date", s)
+	}
+
+	return time.Time{}, errUnsupported
+}
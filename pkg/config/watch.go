@@ -0,0 +1,270 @@
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	goconfigLogger "github.com/boolka/goconfig/pkg/logger"
+	"github.com/boolka/goconfig/pkg/source"
+)
+
+const defaultWatchInterval = 10 * time.Second
+
+// Change describes the value change of subscribed path after configuration reload
+type Change struct {
+	Path string
+	Old  any
+	New  any
+}
+
+type subscription struct {
+	path string
+	fn   func(old, new any)
+}
+
+// OnChange registers fn to be called when the value of path changes after configuration reload
+// or runtime override (see Set). Missing value is passed as nil. Reload callbacks are called
+// sequentially from the watching goroutine, override callbacks from the goroutine calling Set.
+// Returned function cancels the registration.
+func (c *Config) OnChange(path string, fn func(old, new any)) func() {
+	s := c.state
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.subs == nil {
+		s.subs = map[int]*subscription{}
+	}
+
+	id := s.nextSub
+	s.nextSub++
+	s.subs[id] = &subscription{
+		path: c.abs(path),
+		fn:   fn,
+	}
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		delete(s.subs, id)
+	}
+}
+
+// Subscribe returns channel that receives changes of the path value after configuration reload.
+// Sending never blocks reloads and overrides: if the previous change was not received yet then
+// it is replaced by the combined one (Old of the previous, New of the latest). The channel is
+// closed when ctx is done.
+func (c *Config) Subscribe(ctx context.Context, path string) <-chan Change {
+	ch := make(chan Change, 1)
+
+	// guards sending against closing, it is held only for non blocking operations
+	var mu sync.Mutex
+	var closed bool
+
+	unsubscribe := c.OnChange(path, func(old, new any) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if closed {
+			return
+		}
+
+		change := Change{Path: path, Old: old, New: new}
+
+		select {
+		case pending := <-ch:
+			change.Old = pending.Old
+		default:
+		}
+
+		// the only slot is free, the receiver can not fill it
+		ch <- change
+	})
+
+	go func() {
+		<-ctx.Done()
+		unsubscribe()
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		closed = true
+		close(ch)
+	}()
+
+	return ch
+}
+
+// Close stops watching configuration files. It is safe to call Close multiple times
+// or if the Watch option is disabled.
+func (c *Config) Close() error {
+	s := c.state
+
+	s.mu.RLock()
+	stop, done := s.stop, s.done
+	s.mu.RUnlock()
+
+	if stop != nil {
+		stop()
+		<-done
+	}
+
+	return nil
+}
+
+// start polling files, the watching context keeps the values (logger) of ctx
+func (s *state) watch(ctx context.Context, interval time.Duration, logger *slog.Logger, onError func(error)) error {
+	fp, err := fingerprint(s.settings)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := context.WithCancel(context.WithoutCancel(ctx))
+
+	s.fingerprint = fp
+	s.stop = stop
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if err := s.reload(ctx); err != nil {
+				if logger != nil {
+					logger.ErrorContext(ctx, err.Error())
+				}
+
+				if onError != nil {
+					onError(err)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// reload sources if files were changed and notify subscribers. On failure the loaded sources are kept.
+func (s *state) reload(ctx context.Context) error {
+	fp, err := fingerprint(s.settings)
+	if err != nil {
+		return fmt.Errorf("reload error: %w", err)
+	}
+
+	// do not report the same broken state twice
+	if fp == s.fingerprint || fp == s.failed {
+		return nil
+	}
+
+	sources, err := load(ctx, s.settings)
+	if err != nil {
+		s.failed = fp
+
+		return fmt.Errorf("reload error: %w", err)
+	}
+
+	s.mu.Lock()
+	old := s.sources
+	s.sources = s.layer(sources)
+	s.fingerprint = fp
+	s.failed = ""
+	sources = s.sources
+	subs := s.subscriptions()
+	s.mu.Unlock()
+
+	if logger, ok := goconfigLogger.LoggerFromContext(ctx); ok {
+		logger.DebugContext(ctx, fmt.Sprintf("configuration reloaded, %d sources", len(sources)))
+	}
+
+	notify(ctx, subs, old, sources)
+
+	return nil
+}
+
+// subscriptions returns copy of registered subscriptions, the lock must be held
+func (s *state) subscriptions() []*subscription {
+	subs := make([]*subscription, 0, len(s.subs))
+	for _, sub := range s.subs {
+		subs = append(subs, sub)
+	}
+
+	return subs
+}
+
+// notify subscribers which values differ between old and new sources
+func notify(ctx context.Context, subs []*subscription, old, sources []*source.Source) {
+	for _, sub := range subs {
+		oldV, oldOk := searchSources(ctx, old, sub.path)
+		newV, newOk := searchSources(ctx, sources, sub.path)
+
+		if !oldOk {
+			oldV = nil
+		}
+
+		if !newOk {
+			newV = nil
+		}
+
+		if oldOk != newOk || !reflect.DeepEqual(oldV, newV) {
+			sub.fn(oldV, newV)
+		}
+	}
+}
+
+// fingerprint hashes names and contents of all files in configuration directories
+func fingerprint(set *settings) (string, error) {
+	h := sha256.New()
+
+	for i, dirFs := range set.dirFs {
+		err := fs.WalkDir(dirFs, set.directory, func(fpath string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if fpath != set.directory && strings.HasPrefix(d.Name(), ".") {
+				if d.IsDir() {
+					return fs.SkipDir
+				}
+
+				return nil
+			}
+
+			if d.IsDir() {
+				return nil
+			}
+
+			data, err := fs.ReadFile(dirFs, fpath)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(h, "%d:%s:%d:", i, fpath, len(data))
+			h.Write(data)
+
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,55 @@
+package config_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/boolka/goconfig/pkg/config"
+)
+
+func TestArrayPath(t *testing.T) {
+	t.Setenv("ARRAYS_SERVER_0_HOST", "env.internal")
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory: "testdata/arrays",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, ok := cfg.Get(ctx, "servers.0.host"); !ok || v != "env.internal" {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.Get(ctx, "servers.1.port"); !ok || v != 8002 {
+		t.Fatal(v, ok)
+	}
+
+	// arrays are not merged, every source indexes its own array
+	if v, ok := cfg.Get(ctx, "servers.-1.host"); !ok || v != "env.internal" {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.Get(ctx, "servers.-1.host", "default"); !ok || v != "c.internal" {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.Get(ctx, "servers.3.host"); ok {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.Get(ctx, "servers.*.port"); !ok || !reflect.DeepEqual(v, []any{8001, 8002, 8003}) {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.Get(ctx, "servers.*.host", "default"); !ok || !reflect.DeepEqual(v, []any{"a.internal", "b.internal", "c.internal"}) {
+		t.Fatal(v, ok)
+	}
+
+	if v, err := config.GetAs[[]string](ctx, cfg, "servers.*.host", "default"); err != nil || len(v) != 3 {
+		t.Fatal(v, err)
+	}
+}
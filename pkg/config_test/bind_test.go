@@ -0,0 +1,130 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/boolka/goconfig/pkg/config"
+)
+
+type bindServer struct {
+	Host     string `goconfig:"host"`
+	Port     int    `goconfig:"port"`
+	Timeout  uint   `goconfig:"timeout"`
+	Password string `goconfig:"password"`
+}
+
+type bindCommon struct {
+	Name string `goconfig:"name"`
+}
+
+func TestBind(t *testing.T) {
+	t.Setenv("BIND_SERVER_PORT", "9090")
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory: "testdata/bind",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var server bindServer
+
+	if err := cfg.Bind(ctx, "server", &server); err != nil {
+		t.Fatal(err)
+	}
+
+	if server.Host != "localhost" || server.Port != 9090 || server.Timeout != 30 || server.Password != "server-password" {
+		t.Fatal(server)
+	}
+
+	var servers struct {
+		Servers []bindServer `goconfig:"servers"`
+	}
+
+	// password is absent in servers array tables
+	if err := cfg.Bind(ctx, "", &servers); !errors.Is(err, config.ErrNotFound) {
+		t.Fatal(err)
+	}
+}
+
+func TestBindErrors(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory: "testdata/bind",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var dst struct {
+		Name    int    `goconfig:"name"`
+		Missing string `goconfig:"server.missing"`
+		Host    string `goconfig:"server.host"`
+	}
+
+	err = cfg.Bind(ctx, "", &dst)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if !errors.Is(err, config.ErrTypeMismatch) || !errors.Is(err, config.ErrNotFound) {
+		t.Fatal(err)
+	}
+
+	var fieldErr *config.FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "Name" || fieldErr.Path != "name" {
+		t.Fatal(err)
+	}
+
+	if dst.Host != "localhost" {
+		t.Fatal(dst.Host)
+	}
+
+	if err := cfg.Bind(ctx, "", dst); err == nil {
+		t.Fatal("expected non pointer error")
+	}
+}
+
+func TestBindNested(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory: "testdata/bind",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var dst struct {
+		bindCommon
+		Server *struct {
+			Host string `goconfig:"host"`
+		} `goconfig:"server"`
+		Servers []struct {
+			Host string `goconfig:"host"`
+			Port int    `goconfig:"port"`
+		} `goconfig:"servers"`
+		Debug bool `goconfig:"debug,optional"`
+	}
+
+	if err := cfg.Bind(ctx, "", &dst); err != nil {
+		t.Fatal(err)
+	}
+
+	if dst.Name != "service" || dst.Server == nil || dst.Server.Host != "localhost" || dst.Debug {
+		t.Fatal(dst)
+	}
+
+	if len(dst.Servers) != 2 || dst.Servers[0].Host != "a.internal" || dst.Servers[1].Port != 2 {
+		t.Fatal(dst.Servers)
+	}
+}
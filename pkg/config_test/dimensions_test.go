@@ -0,0 +1,81 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/boolka/goconfig/pkg/config"
+	"github.com/boolka/goconfig/pkg/source"
+)
+
+func TestDimensions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory:  "testdata/dimensions",
+		Deployment: "production",
+		Dimensions: []source.Dimension{
+			{Name: "dc", Value: "ams"},
+			{Name: "zone", Value: "ams-2"},
+			{Name: "tenant", Value: "acme"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string]string{
+		"a": "default@dc=ams@tenant=acme.toml",
+		"b": "default@tenant=acme.toml",
+		"c": "production@dc=ams.toml",
+		"d": "default.toml",
+	} {
+		if v, ok := cfg.Get(ctx, path); !ok || v != want {
+			t.Fatal(path, v, ok)
+		}
+	}
+
+	origins, err := cfg.Explain(ctx, "c")
+	if err != nil || len(origins) != 4 {
+		t.Fatal(origins, err)
+	}
+
+	for i, file := range []string{"production@dc=ams.toml", "production.toml", "default@dc=ams.toml", "default.toml"} {
+		if origins[i].File != file {
+			t.Fatal(i, origins[i])
+		}
+	}
+
+	if len(origins[0].Dimensions) != 1 || origins[0].Dimensions[0] != (source.Dimension{Name: "dc", Value: "ams"}) {
+		t.Fatal(origins[0])
+	}
+
+	// qualified files are ignored without dimensions
+	cfg, err = config.New(ctx, config.Options{
+		Directory: "testdata/dimensions",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, ok := cfg.Get(ctx, "a"); !ok || v != "default.toml" {
+		t.Fatal(v, ok)
+	}
+
+	for _, dims := range [][]source.Dimension{
+		{{Name: "dc", Value: "ams"}, {Name: "dc", Value: "fra"}},
+		{{Name: "dc", Value: ""}},
+		{{Name: "dc@zone", Value: "ams"}},
+		{{Name: "dc", Value: "ams=1"}},
+	} {
+		if _, err := config.New(ctx, config.Options{
+			Directory:  "testdata/dimensions",
+			Dimensions: dims,
+		}); !errors.Is(err, config.ErrInvalidDimension) {
+			t.Fatal(dims, err)
+		}
+	}
+}
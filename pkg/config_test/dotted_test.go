@@ -0,0 +1,45 @@
+package config_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/boolka/goconfig/pkg/config"
+)
+
+func TestDottedKeys(t *testing.T) {
+	t.Setenv("DOTTED_EXAMPLE_ORG_TIMEOUT", "30")
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory: "testdata/dotted",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, ok := cfg.Get(ctx, `domains."example.com".timeout`); !ok || v != 10 {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.Get(ctx, `domains[example.com].timeout`); !ok || v != 10 {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.GetPath(ctx, []string{"domains", "example.com", "timeout"}); !ok || v != 10 {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.GetPath(ctx, []string{"domains", "example.org", "timeout"}); !ok || v != "30" {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.GetPath(ctx, []string{"domains", "example.org", "timeout"}, "default"); !ok || v != 20 {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.Get(ctx, "domains.example.com.timeout"); ok {
+		t.Fatal(v, ok)
+	}
+}
@@ -0,0 +1,83 @@
+package config_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/boolka/goconfig/pkg/config"
+)
+
+func TestDuplicateSources(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	directory := "testdata/duplicates/a" + string(os.PathListSeparator) + "testdata/duplicates/b"
+
+	t.Run("merge", func(t *testing.T) {
+		cfg, err := config.New(ctx, config.Options{
+			Directory:  directory,
+			Deployment: "production",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the earlier directory and then the filename order wins
+		for path, expected := range map[string]any{
+			"source":     "a/default.json",
+			"json":       true,
+			"yaml":       true,
+			"deployment": "a/production.toml",
+			"only_b":     true,
+		} {
+			if v, ok := cfg.Get(ctx, path); !ok || v != expected {
+				t.Fatal(path, v, ok)
+			}
+		}
+	})
+
+	t.Run("warn", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		_, err := config.New(ctx, config.Options{
+			Directory:       directory,
+			Deployment:      "production",
+			DuplicatePolicy: config.DuplicateWarn,
+			Logger:          slog.New(slog.NewTextHandler(&logs, nil)),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.Contains(logs.String(), "level=WARN") || !strings.Contains(logs.String(), filepath.Join("testdata/duplicates/a", "default.json")+" and "+filepath.Join("testdata/duplicates/a", "default.yaml")) {
+			t.Fatal(logs.String())
+		}
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		_, err := config.New(ctx, config.Options{
+			Directory:       directory,
+			Deployment:      "production",
+			DuplicatePolicy: config.DuplicateFail,
+		})
+		if !errors.Is(err, config.ErrDuplicateSource) || !strings.Contains(err.Error(), filepath.Join("testdata/duplicates/a", "production.toml")+" and "+filepath.Join("testdata/duplicates/b", "production.toml")) {
+			t.Fatal(err)
+		}
+
+		// files of layout subdirectories are parts of the layer
+		if _, err := config.New(ctx, config.Options{
+			Directory:       "testdata/layout",
+			Deployment:      "production",
+			Layout:          config.LayoutDirs,
+			DuplicatePolicy: config.DuplicateFail,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package config_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/boolka/goconfig/pkg/config"
+	"github.com/boolka/goconfig/pkg/source"
+)
+
+func TestExplain(t *testing.T) {
+	t.Setenv("TEST_FILE_ENV", "config_file_env_custom")
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory:  "testdata/config",
+		Instance:   "1",
+		Deployment: "testing",
+		Hostname:   "host-name",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	origins, err := cfg.Explain(ctx, "local-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"local-1.toml",
+		"local.toml",
+		"host-name-testing-1.toml",
+		"host-name-testing.toml",
+		"host-name-1.toml",
+		"host-name.toml",
+		"testing-1.yaml",
+		"testing.yaml",
+		"default-1.json",
+		"default.json",
+	}
+
+	if len(origins) != len(expected) {
+		t.Fatal(origins)
+	}
+
+	for i, origin := range origins {
+		if origin.File != expected[i] || !origin.Resolved || origin.Winner != (i == 0) {
+			t.Fatal(i, origin)
+		}
+	}
+
+	if origins[0].Type != source.LocInstSrc || origins[0].Instance != "1" || origins[0].Value != "local-1.toml" {
+		t.Fatal(origins[0])
+	}
+
+	// hostname is reported for host files only
+	for _, origin := range origins {
+		var hostname string
+		if strings.HasPrefix(origin.File, "host-name") {
+			hostname = "host-name"
+		}
+
+		if origin.Hostname != hostname {
+			t.Fatal(origin)
+		}
+	}
+
+	origins, err = cfg.Explain(ctx, "env")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(origins) == 0 || origins[0].Type != source.EnvSrc || origins[0].Reference != "TEST_FILE_ENV" || !origins[0].Resolved || !origins[0].Winner {
+		t.Fatal(origins)
+	}
+
+	t.Setenv("TEST_FILE_ENV", "")
+	os.Unsetenv("TEST_FILE_ENV")
+
+	origins, err = cfg.Explain(ctx, "env")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if origins[0].Type != source.EnvSrc || origins[0].Resolved || origins[0].Winner || !origins[1].Winner {
+		t.Fatal(origins)
+	}
+
+	if origins, err := cfg.Explain(ctx, "not_exist"); err != nil || len(origins) != 0 {
+		t.Fatal(origins, err)
+	}
+}
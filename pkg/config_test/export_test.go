@@ -0,0 +1,79 @@
+package config_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/boolka/goconfig/pkg/config"
+)
+
+func TestExport(t *testing.T) {
+	t.Setenv("EXPORT_SERVER_TOKEN", "token")
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory:  "testdata/export",
+		Deployment: "production",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, ok := cfg.Get(ctx, "")
+	if !ok {
+		t.Fatal(want, ok)
+	}
+
+	for _, format := range []config.Format{config.FormatJSON, config.FormatYAML, config.FormatTOML} {
+		t.Run(string(format), func(t *testing.T) {
+			var b bytes.Buffer
+
+			if err := cfg.Export(ctx, &b, format); err != nil {
+				t.Fatal(err)
+			}
+
+			if !strings.Contains(b.String(), "prod.internal:8080") || !strings.Contains(b.String(), "token") {
+				t.Fatal(b.String())
+			}
+
+			// exported configuration is loaded back the same
+			dir := t.TempDir()
+
+			if err := os.WriteFile(filepath.Join(dir, "default."+string(format)), b.Bytes(), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			exported, err := config.New(ctx, config.Options{
+				Directory: dir,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, path := range []string{"name", "debug", "server.host", "server.port", "server.url", "server.token", "server.tags"} {
+				v, _ := cfg.Get(ctx, path)
+
+				if ev, ok := exported.Get(ctx, path); !ok || !reflect.DeepEqual(v, ev) {
+					t.Fatal(path, v, ev)
+				}
+			}
+		})
+	}
+
+	var b bytes.Buffer
+
+	if err := cfg.Sub("server").Export(ctx, &b, config.FormatJSON); err != nil || strings.Contains(b.String(), "service") || !strings.Contains(b.String(), `"port": 8080`) {
+		t.Fatal(b.String(), err)
+	}
+
+	if err := cfg.Export(ctx, &b, "xml"); !errors.Is(err, config.ErrUnknownFormat) {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,88 @@
+package config_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/boolka/goconfig/pkg/config"
+	"github.com/boolka/goconfig/pkg/datamap"
+)
+
+func init() {
+	// "a.b=value" lines are decoded into nested tables
+	datamap.RegisterFormat(".props", func(r io.Reader) (map[string]any, error) {
+		data := map[string]any{}
+		scanner := bufio.NewScanner(r)
+
+		for scanner.Scan() {
+			if k, v, ok := strings.Cut(scanner.Text(), "="); ok {
+				keys := strings.Split(k, ".")
+				table := data
+
+				for _, key := range keys[:len(keys)-1] {
+					next, ok := table[key].(map[string]any)
+					if !ok {
+						next = map[string]any{}
+						table[key] = next
+					}
+
+					table = next
+				}
+
+				table[keys[len(keys)-1]] = v
+			}
+		}
+
+		return data, scanner.Err()
+	})
+}
+
+func TestRegisteredFormat(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	var logs bytes.Buffer
+
+	// files of unregistered formats are ignored with warning
+	cfg, err := config.New(ctx, config.Options{
+		Directory:  "testdata/formats",
+		Deployment: "production",
+		Logger:     slog.New(slog.NewTextHandler(&logs, nil)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(logs.String(), "level=WARN") || !strings.Contains(logs.String(), "production.ymal") || strings.Contains(logs.String(), "default.props") {
+		t.Fatal(logs.String())
+	}
+
+	if v, ok := cfg.Get(ctx, "database.host"); !ok || v != "localhost" {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.Get(ctx, "database.port"); !ok || v != 6432 {
+		t.Fatal(v, ok)
+	}
+}
+
+func TestUnregisteredFormatStrict(t *testing.T) {
+	t.Parallel()
+
+	// mistyped extension is reported
+	_, err := config.New(context.Background(), config.Options{
+		Directory:  "testdata/formats",
+		Deployment: "production",
+		Strict:     true,
+	})
+	if !errors.Is(err, config.ErrUnrecognizedFile) || !strings.Contains(err.Error(), `production.ymal (unknown format ".ymal")`) || strings.Contains(err.Error(), "default.props") {
+		t.Fatal(err)
+	}
+}
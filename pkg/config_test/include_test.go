@@ -0,0 +1,77 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/boolka/goconfig/pkg/config"
+	"github.com/boolka/goconfig/pkg/datamap"
+)
+
+func TestInclude(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory:  "testdata/include",
+		Deployment: "production",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, ok := cfg.Get(ctx, "kafka"); !ok || !reflect.DeepEqual(v, map[string]any{
+		"brokers":    []any{"kafka-1:9092", "kafka-2:9092"},
+		"topic":      "production",
+		"partitions": 1,
+	}) {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.Get(ctx, datamap.IncludeKey); ok {
+		t.Fatal(v, ok)
+	}
+}
+
+func TestIncludeLayoutDirs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	// fragments directory is not a deployment layer
+	for _, layout := range []config.Layout{config.LayoutFiles, config.LayoutDirs, config.LayoutNamespacedDirs} {
+		cfg, err := config.New(ctx, config.Options{
+			Directory:  "testdata/include",
+			Deployment: "production",
+			Layout:     layout,
+			Strict:     true,
+		})
+		if err != nil {
+			t.Fatal(layout, err)
+		}
+
+		if v, ok := cfg.Get(ctx, "kafka.brokers"); !ok || !reflect.DeepEqual(v, []any{"kafka-1:9092", "kafka-2:9092"}) {
+			t.Fatal(layout, v, ok)
+		}
+
+		if v, ok := cfg.Get(ctx, "brokers"); ok {
+			t.Fatal(layout, v, ok)
+		}
+	}
+}
+
+func TestIncludeCycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	_, err := config.New(ctx, config.Options{
+		Directory: "testdata/include_cycle",
+	})
+	if !errors.Is(err, datamap.ErrIncludeCycle) {
+		t.Fatal(err)
+	}
+}
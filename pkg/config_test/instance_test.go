@@ -2,6 +2,7 @@ package config_test
 
 import (
 	"context"
+	"regexp"
 	"testing"
 
 	"github.com/boolka/goconfig/pkg/config"
@@ -27,3 +28,57 @@ func TestMultiInstance(t *testing.T) {
 		t.Fatal(v, ok)
 	}
 }
+
+func TestNamedInstance(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	// instance value is known, so the filename is not misread as deployment
+	cfg, err := config.New(ctx, config.Options{
+		Directory: "testdata/shards",
+		Instance:  "eu1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, ok := cfg.Get(ctx, "shard"); !ok || v != "default-eu1.toml" {
+		t.Fatal(v, ok)
+	}
+
+	cfg, err = config.New(ctx, config.Options{
+		Directory:  "testdata/shards",
+		Deployment: "production",
+		Instance:   "blue",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, ok := cfg.Get(ctx, "color"); !ok || v != "production-blue.toml" {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.Get(ctx, "shard"); !ok || v != "default.toml" {
+		t.Fatal(v, ok)
+	}
+
+	cfg, err = config.New(ctx, config.Options{
+		Directory:       "testdata/shards",
+		Deployment:      "production",
+		InstancePattern: regexp.MustCompile(`^(blue|green|eu\d+)$`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, ok := cfg.Get(ctx, "color"); !ok || v != "production.toml" {
+		t.Fatal(v, ok)
+	}
+
+	origins, err := cfg.Explain(ctx, "color")
+	if err != nil || len(origins) != 2 {
+		t.Fatal(origins, err)
+	}
+}
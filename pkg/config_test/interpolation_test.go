@@ -0,0 +1,63 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/boolka/goconfig/pkg/config"
+)
+
+func TestInterpolation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory: "testdata/interpolation",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// local.toml override propagates to references
+	if v, ok := cfg.Get(ctx, "api.url"); !ok || v != "https://local.internal:8443" {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.Get(ctx, "metrics.url", "default"); !ok || v != "https://local.internal:9090" {
+		t.Fatal(v, ok)
+	}
+
+	// single reference keeps referenced type
+	if v, ok := cfg.Get(ctx, "api.port_ref"); !ok || v != 8443 {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.Get(ctx, "api"); !ok || !reflect.DeepEqual(v, map[string]any{
+		"port":     8443,
+		"url":      "https://local.internal:8443",
+		"port_ref": 8443,
+	}) {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.Get(ctx, "literal"); !ok || v != "${host} costs $5" {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.Get(ctx, "unterminated"); !ok || v != "${host" {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.Get(ctx, "missing"); ok || !errors.Is(v.(error), config.ErrNotFound) {
+		t.Fatal(v, ok)
+	}
+
+	for _, path := range []string{"cycle.a", "cycle.self", "cycle"} {
+		if v, ok := cfg.Get(ctx, path); ok || !errors.Is(v.(error), config.ErrInterpolationCycle) {
+			t.Fatal(path, v, ok)
+		}
+	}
+}
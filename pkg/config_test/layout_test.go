@@ -0,0 +1,104 @@
+package config_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/boolka/goconfig/pkg/config"
+	"github.com/boolka/goconfig/pkg/source"
+)
+
+func TestLayoutDirs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory:  "testdata/layout",
+		Deployment: "production",
+		Layout:     config.LayoutDirs,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// keys of files inside directories are merged at the root
+	if v, ok := cfg.Get(ctx, "topic"); !ok || v != "production/kafka.toml" {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.Get(ctx, "host"); !ok || v != "production/database.json" {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.Get(ctx, "port"); !ok || v != 5432 {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.Get(ctx, "database.host"); !ok || v != "default.toml" {
+		t.Fatal(v, ok)
+	}
+}
+
+func TestLayoutNamespacedDirs(t *testing.T) {
+	t.Setenv("LAYOUT_DATABASE_PASSWORD", "secret")
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory:  "testdata/layout",
+		Deployment: "production",
+		Layout:     config.LayoutNamespacedDirs,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, ok := cfg.Get(ctx, "database"); !ok || !reflect.DeepEqual(v, map[string]any{
+		"host":     "production/database.json",
+		"port":     5432,
+		"password": "secret",
+	}) {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.Get(ctx, "kafka.topic"); !ok || v != "production/kafka.toml" {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.Get(ctx, "kafka.brokers.0"); !ok || v != "kafka-1:9092" {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.Get(ctx, "*.host"); !ok || !reflect.DeepEqual(v, []any{"production/database.json"}) {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.Get(ctx, "name"); !ok || v != "default.toml" {
+		t.Fatal(v, ok)
+	}
+
+	origins, err := cfg.Explain(ctx, "database.password")
+	if err != nil || len(origins) != 1 || origins[0].Type != source.EnvSrc || origins[0].Reference != "LAYOUT_DATABASE_PASSWORD" || origins[0].File != "env/database.toml" {
+		t.Fatal(origins, err)
+	}
+
+	origins, err = cfg.Explain(ctx, "database.host")
+	if err != nil || len(origins) != 3 || origins[0].Type != source.DepSrc || origins[0].Deployment != "production" {
+		t.Fatal(origins, err)
+	}
+
+	// directories are ignored by default
+	cfg, err = config.New(ctx, config.Options{
+		Directory:  "testdata/layout",
+		Deployment: "production",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, ok := cfg.Get(ctx, "kafka"); ok {
+		t.Fatal(v, ok)
+	}
+}
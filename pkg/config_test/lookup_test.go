@@ -0,0 +1,106 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/boolka/goconfig/pkg/config"
+	"github.com/boolka/goconfig/pkg/source"
+)
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory: "testdata/lookup",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, err := cfg.Lookup(ctx, "host"); err != nil || v != "api.internal" {
+		t.Fatal(v, err)
+	}
+
+	if v, err := cfg.Lookup(ctx, "server"); err != nil || v.(map[string]any)["port"] != 8080 {
+		t.Fatal(v, err)
+	}
+
+	if v, err := cfg.Lookup(ctx, "server.port", "default"); err != nil || v != 8080 {
+		t.Fatal(v, err)
+	}
+
+	var srcErr *config.SourceError
+
+	if v, err := cfg.Lookup(ctx, "not_exist"); !errors.Is(err, config.ErrNotFound) || errors.As(err, &srcErr) {
+		t.Fatal(v, err)
+	}
+
+	if v, err := cfg.Lookup(ctx, "broken"); !errors.As(err, &srcErr) || srcErr.File != "default.toml" || srcErr.Type != source.DefSrc || srcErr.Path != "broken" {
+		t.Fatal(v, err)
+	}
+
+	// broken reference is not shadowed by the lower source
+	if v, err := cfg.Lookup(ctx, "port"); !errors.As(err, &srcErr) || srcErr.File != "local.toml" || srcErr.Type != source.LocSrc {
+		t.Fatal(v, err)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	if v, err := cfg.Lookup(canceled, "host"); !errors.Is(err, context.Canceled) {
+		t.Fatal(v, err)
+	}
+}
+
+// failingStore is unavailable in-house storage
+type failingStore struct{}
+
+func (failingStore) Get(context.Context, string) (any, bool) {
+	return errors.New("storage is unavailable"), false
+}
+
+func TestLookupFailedSource(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory: "testdata/lookup",
+		Tiers:     []string{"store"},
+		Sources: []*source.Source{{
+			Originer: failingStore{},
+			Tier:     "store",
+			FilePath: "store",
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// failure of the higher source does not hide the value of the lower one
+	if v, err := cfg.Lookup(ctx, "host"); err != nil || v != "api.internal" {
+		t.Fatal(v, err)
+	}
+
+	if v, err := config.GetAs[string](ctx, cfg, "host"); err != nil || v != "api.internal" {
+		t.Fatal(v, err)
+	}
+
+	var dst struct {
+		Host string `goconfig:"host"`
+	}
+
+	if err := cfg.Bind(ctx, "", &dst); err != nil || dst.Host != "api.internal" {
+		t.Fatal(dst, err)
+	}
+
+	var srcErr *config.SourceError
+
+	if v, err := cfg.Lookup(ctx, "not_exist"); !errors.As(err, &srcErr) || srcErr.File != "store" || errors.Is(err, config.ErrNotFound) {
+		t.Fatal(v, err)
+	}
+}
@@ -0,0 +1,298 @@
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/boolka/goconfig/pkg/config"
+	"github.com/boolka/goconfig/pkg/source"
+)
+
+func TestSet(t *testing.T) {
+	t.Setenv("MEMORY_FEATURE_LIMIT", "20")
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory: "testdata/memory",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// memory source without overrides holds no values
+	origins, err := cfg.Explain(ctx, "")
+	if err != nil || len(origins) == 0 || !origins[0].Winner {
+		t.Fatal(origins, err)
+	}
+
+	for _, origin := range origins {
+		if origin.Type == source.MemSrc {
+			t.Fatal(origin)
+		}
+	}
+
+	changes := make(chan config.Change, 1)
+	cfg.OnChange("feature.enabled", func(old, new any) {
+		changes <- config.Change{Old: old, New: new}
+	})
+
+	if err := cfg.Set(ctx, "feature.enabled", true); err != nil {
+		t.Fatal(err)
+	}
+
+	if change := <-changes; change.Old != false || change.New != true {
+		t.Fatal(change)
+	}
+
+	if v, ok := cfg.Get(ctx, "feature.enabled"); !ok || v != true {
+		t.Fatal(v, ok)
+	}
+
+	// memory source is above environment
+	if err := cfg.Sub("feature").Set(ctx, "limit", 30); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, ok := cfg.Get(ctx, "feature"); !ok || !reflect.DeepEqual(v, map[string]any{"enabled": true, "limit": 30}) {
+		t.Fatal(v, ok)
+	}
+
+	origins, err = cfg.Explain(ctx, "feature.limit")
+	if err != nil || len(origins) != 3 || origins[0].Type != source.MemSrc || !origins[0].Winner {
+		t.Fatal(origins, err)
+	}
+
+	cfg.Unset(ctx, "feature.limit")
+
+	if v, ok := cfg.Get(ctx, "feature.limit"); !ok || v != "20" {
+		t.Fatal(v, ok)
+	}
+
+	cfg.Unset(ctx, "feature")
+
+	if change := <-changes; change.Old != true || change.New != false {
+		t.Fatal(change)
+	}
+
+	if err := cfg.Set(ctx, "feature.*", 1); err == nil {
+		t.Fatal("wildcard path is set")
+	}
+
+	if err := cfg.Set(ctx, "", 1); err == nil {
+		t.Fatal("empty path is set")
+	}
+}
+
+func TestOverride(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory: "testdata/memory",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restoreOuter, err := cfg.Override(ctx, "feature.limit", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restoreInner, err := cfg.Override(ctx, "feature.limit", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, ok := cfg.Get(ctx, "feature.limit"); !ok || v != 2 {
+		t.Fatal(v, ok)
+	}
+
+	restoreInner()
+
+	if v, ok := cfg.Get(ctx, "feature.limit"); !ok || v != 1 {
+		t.Fatal(v, ok)
+	}
+
+	restoreOuter()
+
+	if v, ok := cfg.Get(ctx, "feature.limit"); !ok || v != 10 {
+		t.Fatal(v, ok)
+	}
+}
+
+// contextStore is storage which fails when the context is done
+type contextStore map[string]any
+
+func (s contextStore) Get(ctx context.Context, path string) (any, bool) {
+	if err := ctx.Err(); err != nil {
+		return err, false
+	}
+
+	v, ok := s[path]
+
+	return v, ok
+}
+
+func TestOverrideRestoreCanceled(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.New(context.Background(), config.Options{
+		Directory: "testdata/memory",
+		Tiers:     []string{"store"},
+		Sources: []*source.Source{{
+			Originer: contextStore{"name": "store"},
+			Tier:     "store",
+			FilePath: "store",
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	restore, err := cfg.Override(ctx, "name", "memory")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var changes []any
+	cfg.OnChange("name", func(old, new any) {
+		changes = append(changes, old, new)
+	})
+
+	// restore works after the context of the call is done
+	cancel()
+	restore()
+
+	if len(changes) != 2 || changes[0] != "memory" || changes[1] != "store" {
+		t.Fatal(changes)
+	}
+}
+
+func TestSetConcurrent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory: "testdata/memory",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+
+	for i := range 10 {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			if err := cfg.Set(ctx, "feature.limit", i); err != nil {
+				t.Error(err)
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			if _, ok := cfg.Get(ctx, "feature.limit"); !ok {
+				t.Error("feature.limit is not found")
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestSetReload(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "default.toml")
+
+	if err := os.WriteFile(file, []byte("field = \"initial\"\nother = 1"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory:     dir,
+		Watch:         true,
+		WatchInterval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		cfg.Close()
+	})
+
+	if err := cfg.Set(ctx, "field", "memory"); err != nil {
+		t.Fatal(err)
+	}
+
+	changes := cfg.Subscribe(ctx, "other")
+
+	replaceFile(t, file, "field = \"changed\"\nother = 2")
+
+	select {
+	case <-changes:
+	case <-time.After(5 * time.Second):
+		t.Fatal("change was not received")
+	}
+
+	if v, ok := cfg.Get(ctx, "field"); !ok || v != "memory" {
+		t.Fatal(v, ok)
+	}
+}
+
+func TestSetArrayElement(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory: "testdata/memory_arrays",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	servers := []any{map[string]any{"host": "a"}, map[string]any{"host": "b"}}
+
+	if err := cfg.Set(ctx, "servers.0.host", "z"); err == nil {
+		t.Fatal("array element is set")
+	}
+
+	if v, ok := cfg.Get(ctx, "servers"); !ok || !reflect.DeepEqual(v, servers) {
+		t.Fatal(v, ok)
+	}
+
+	// the whole array is overridden
+	servers[0] = map[string]any{"host": "z"}
+
+	if err := cfg.Set(ctx, "servers", servers); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, ok := cfg.Get(ctx, "servers.1.host"); !ok || v != "b" {
+		t.Fatal(v, ok)
+	}
+
+	if err := cfg.Set(ctx, "servers.1", map[string]any{}); err == nil {
+		t.Fatal("array element of override is set")
+	}
+}
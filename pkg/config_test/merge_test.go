@@ -0,0 +1,53 @@
+package config_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/boolka/goconfig/pkg/config"
+)
+
+func TestMergeTables(t *testing.T) {
+	t.Setenv("MERGE_DATABASE_PASSWORD", "secret")
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory: "testdata/merge",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]any{
+		"host":     "local.toml",
+		"port":     5432,
+		"password": "secret",
+		"pool": map[string]any{
+			"min": 1,
+			"max": 20,
+		},
+	}
+
+	v, ok := cfg.Get(ctx, "database")
+	if !ok || !reflect.DeepEqual(v, expected) {
+		t.Fatal(v, ok)
+	}
+
+	// returned table is a copy
+	v.(map[string]any)["host"] = "modified"
+
+	if v, ok := cfg.Get(ctx, "database.host"); !ok || v != "local.toml" {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.Get(ctx, "database.pool", "default"); !ok || !reflect.DeepEqual(v, map[string]any{"min": 1, "max": 10}) {
+		t.Fatal(v, ok)
+	}
+
+	// scalar shadows tables of lower sources
+	if v, ok := cfg.Get(ctx, "scalar"); !ok || v != "local.toml" {
+		t.Fatal(v, ok)
+	}
+}
@@ -0,0 +1,99 @@
+package config_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/boolka/goconfig/pkg/config"
+	"github.com/boolka/goconfig/pkg/source"
+)
+
+// store is in-house key/value storage
+type store map[string]any
+
+func (s store) Get(_ context.Context, path string) (any, bool) {
+	v, ok := s[path]
+
+	return v, ok
+}
+
+// newSecretsFile decodes "path=value" lines
+func newSecretsFile(_ context.Context, dirFs fs.ReadDirFS, fpath string) (source.Originer, error) {
+	f, err := dirFs.Open(fpath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	s := store{}
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		if path, v, ok := strings.Cut(scanner.Text(), "="); ok {
+			s[path] = v
+		}
+	}
+
+	return s, scanner.Err()
+}
+
+func TestCustomSources(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory: "testdata/plugins",
+		Tiers:     []string{"secrets", "store"},
+		Sources: []*source.Source{{
+			Originer: store{"database.user": "from-store", "database.password": "from-store"},
+			Tier:     "store",
+			FilePath: "in-house store",
+		}},
+		Constructors: map[string]source.Constructor{
+			"secrets": newSecretsFile,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, expected := range map[string]any{
+		"database.host":     "localhost",
+		"database.user":     "from-store",
+		"database.password": "from-secrets-file",
+	} {
+		if v, ok := cfg.Get(ctx, path); !ok || v != expected {
+			t.Fatal(path, v, ok)
+		}
+	}
+
+	origins, err := cfg.Explain(ctx, "database.user")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(origins) != 2 || origins[0].File != "in-house store" || origins[0].Tier != "store" || !origins[0].Type.IsTier() {
+		t.Fatal(origins)
+	}
+
+	for _, options := range []config.Options{{
+		Directory: "testdata/plugins",
+		Sources:   []*source.Source{{Originer: store{}, Tier: "store"}},
+	}, {
+		Directory: "testdata/plugins",
+		Tiers:     []string{"store"},
+		Sources:   []*source.Source{{Tier: "store"}},
+	}, {
+		Directory:    "testdata/plugins",
+		Constructors: map[string]source.Constructor{"env": newSecretsFile},
+	}} {
+		if _, err := config.New(ctx, options); !errors.Is(err, config.ErrInvalidSource) {
+			t.Fatal(err)
+		}
+	}
+}
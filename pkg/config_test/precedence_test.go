@@ -2,9 +2,12 @@ package config_test
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"testing"
 
 	"github.com/boolka/goconfig/pkg/config"
+	"github.com/boolka/goconfig/pkg/source"
 )
 
 // No instance & deployment
@@ -63,3 +66,98 @@ func TestDeploymentEnvPrecedence(t *testing.T) {
 		t.Fatal(v, ok)
 	}
 }
+
+func TestCustomPrecedence(t *testing.T) {
+	t.Setenv("TIERS_FIELD", "env")
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory: "testdata/tiers",
+		Hostname:  "buildbox",
+		Tiers:     []string{"ci"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// tiers are beneath environment and above local files by default
+	for path, want := range map[string]string{
+		"field": "env",
+		"ci":    "ci.toml",
+		"local": "local.toml",
+		"host":  "local.toml",
+	} {
+		if v, ok := cfg.Get(ctx, path); !ok || v != want {
+			t.Fatal(path, v, ok)
+		}
+	}
+
+	origins, err := cfg.Explain(ctx, "ci")
+	if err != nil || len(origins) != 2 || origins[0].Tier != "ci" || origins[0].Type != source.TierType(0) || origins[0].Type.String() != "tier" {
+		t.Fatal(origins, err)
+	}
+
+	// host files beat local files, tier is the lowest
+	cfg, err = config.New(ctx, config.Options{
+		Directory: "testdata/tiers",
+		Hostname:  "buildbox",
+		Tiers:     []string{"ci"},
+		Precedence: []source.SourceType{
+			source.VaultSrc,
+			source.EnvSrc,
+			source.HostDepInstSrc,
+			source.HostDepSrc,
+			source.HostInstSrc,
+			source.HostSrc,
+			source.LocDepInstSrc,
+			source.LocDepSrc,
+			source.LocInstSrc,
+			source.LocSrc,
+			source.DepInstSrc,
+			source.DepSrc,
+			source.DefInstSrc,
+			source.DefSrc,
+			source.TierType(0),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string]string{
+		"field": "env",
+		"ci":    "default.toml",
+		"local": "local.toml",
+		"host":  "buildbox.toml",
+	} {
+		if v, ok := cfg.Get(ctx, path); !ok || v != want {
+			t.Fatal(path, v, ok)
+		}
+	}
+}
+
+func TestInvalidPrecedence(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	for _, opts := range []config.Options{
+		{Precedence: []source.SourceType{source.EnvSrc, source.VaultSrc}},
+		{Precedence: append(slices.Repeat([]source.SourceType{source.DefSrc}, 2), source.LocSrc)},
+		{Precedence: []source.SourceType{source.MemSrc}},
+		{Tiers: []string{"local"}},
+		{Tiers: []string{"ci", "ci"}},
+		{Tiers: []string{"ci"}, Precedence: []source.SourceType{
+			source.VaultSrc, source.EnvSrc, source.LocDepInstSrc, source.LocDepSrc, source.LocInstSrc, source.LocSrc,
+			source.HostDepInstSrc, source.HostDepSrc, source.HostInstSrc, source.HostSrc,
+			source.DepInstSrc, source.DepSrc, source.DefInstSrc, source.DefSrc,
+		}},
+	} {
+		opts.Directory = "testdata/tiers"
+
+		if _, err := config.New(ctx, opts); !errors.Is(err, config.ErrInvalidPrecedence) {
+			t.Fatal(opts, err)
+		}
+	}
+}
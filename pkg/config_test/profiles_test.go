@@ -0,0 +1,61 @@
+package config_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/boolka/goconfig/pkg/config"
+)
+
+func TestDeployments(t *testing.T) {
+	t.Setenv("GO_DEPLOYMENT", "production, eu,canary")
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory: "testdata/profiles",
+		Instance:  "1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string]string{
+		"a": "canary.toml",
+		"b": "eu.toml",
+		"c": "local-eu.toml",
+		"d": "default.toml",
+	} {
+		if v, ok := cfg.Get(ctx, path); !ok || v != want {
+			t.Fatal(path, v, ok)
+		}
+	}
+
+	origins, err := cfg.Explain(ctx, "c")
+	if err != nil || len(origins) != 4 {
+		t.Fatal(origins, err)
+	}
+
+	for i, file := range []string{"local-eu.toml", "production-1.toml", "production.toml", "default.toml"} {
+		if origins[i].File != file {
+			t.Fatal(i, origins[i])
+		}
+	}
+
+	// the order of the list defines precedence
+	cfg, err = config.New(ctx, config.Options{
+		Directory:  "testdata/profiles",
+		Deployment: "canary,production",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, ok := cfg.Get(ctx, "a"); !ok || v != "production.toml" {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.Get(ctx, "c"); !ok || v != "production.toml" {
+		t.Fatal(v, ok)
+	}
+}
@@ -0,0 +1,116 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/boolka/goconfig/pkg/config"
+	"github.com/boolka/goconfig/pkg/schema"
+	"github.com/boolka/goconfig/pkg/source"
+)
+
+func TestSchemaValidation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	s, err := schema.Load(os.DirFS("testdata/schema"), "schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = config.New(ctx, config.Options{
+		Directory: "testdata/schema/config",
+		Schema:    s,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = config.New(ctx, config.Options{
+		Directory:  "testdata/schema/config",
+		Deployment: "production",
+		Schema:     s,
+	})
+
+	var validationErr *schema.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatal(err)
+	}
+
+	expected := []schema.Violation{
+		{Path: "level", Message: `value warning is not one of [debug info error]`},
+		{Path: "server.hots", Message: "unknown key"},
+		{Path: "server.port", Message: "value 70000 is greater than maximum 65535"},
+	}
+
+	if len(validationErr.Violations) != len(expected) {
+		t.Fatal(validationErr)
+	}
+
+	for i, v := range validationErr.Violations {
+		if v != expected[i] {
+			t.Error(i, v)
+		}
+	}
+}
+
+func TestSchemaValidationEnv(t *testing.T) {
+	t.Setenv("SCHEMA_SERVER_PORT", "8080")
+
+	ctx := context.Background()
+
+	s, err := schema.Load(os.DirFS("testdata/schema"), "schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// environment variables are parsed into expected types
+	if _, err := config.New(ctx, config.Options{
+		Directory: "testdata/schema/env",
+		Schema:    s,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("SCHEMA_SERVER_PORT", "http")
+
+	_, err = config.New(ctx, config.Options{
+		Directory: "testdata/schema/env",
+		Schema:    s,
+	})
+
+	var validationErr *schema.ValidationError
+	if !errors.As(err, &validationErr) || len(validationErr.Violations) != 1 || validationErr.Violations[0].Path != "server.port" {
+		t.Fatal(err)
+	}
+}
+
+func TestSchemaValidationFailedSource(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	s, err := schema.Load(os.DirFS("testdata/schema"), "schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = config.New(ctx, config.Options{
+		Directory: "testdata/schema/config",
+		Schema:    s,
+		Tiers:     []string{"store"},
+		Sources: []*source.Source{{
+			Originer: failingStore{},
+			Tier:     "store",
+			FilePath: "store",
+		}},
+	})
+
+	var srcErr *config.SourceError
+	if !errors.As(err, &srcErr) || srcErr.File != "store" {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,189 @@
+package config_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/boolka/goconfig/pkg/config"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSecret(t *testing.T) {
+	t.Setenv("SECRET_DATABASE_PASSWORD", "env-password")
+
+	ctx := context.Background()
+
+	var logs bytes.Buffer
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory: "testdata/secret",
+		Sensitive: []string{"database.password", "database.port", "*.token"},
+		Logger: slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{
+			Level: slog.LevelDebug,
+		})),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// values are returned as is
+	if v, ok := cfg.Get(ctx, "database.password"); !ok || v != "env-password" {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := cfg.Get(ctx, "database"); !ok || v.(map[string]any)["password"] != "env-password" {
+		t.Fatal(v, ok)
+	}
+
+	if _, err := cfg.Lookup(ctx, "api"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = config.GetAs[int](ctx, cfg, "database.port")
+
+	var typeErr *config.TypeError
+	if !errors.As(err, &typeErr) || strings.Contains(err.Error(), "not a number") {
+		t.Fatal(err)
+	}
+
+	if secret, ok := typeErr.Value.(config.Secret); !ok || secret.Value() != "not a number" {
+		t.Fatal(typeErr.Value)
+	}
+
+	var dst struct {
+		Port int `goconfig:"port"`
+	}
+
+	if err := cfg.Sub("database").Bind(ctx, "", &dst); err == nil || strings.Contains(err.Error(), "not a number") {
+		t.Fatal(err)
+	}
+
+	for _, secret := range []string{"env-password", "api-token", "cache-token"} {
+		if strings.Contains(logs.String(), secret) {
+			t.Fatal(logs.String())
+		}
+	}
+
+	if !strings.Contains(logs.String(), "db.internal") || !strings.Contains(logs.String(), "***") {
+		t.Fatal(logs.String())
+	}
+
+	for _, format := range []config.Format{config.FormatJSON, config.FormatYAML, config.FormatTOML} {
+		var b bytes.Buffer
+
+		if err := cfg.Export(ctx, &b, format); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, secret := range []string{"env-password", "default-password", "api-token", "cache-token"} {
+			if strings.Contains(b.String(), secret) {
+				t.Fatal(format, b.String())
+			}
+		}
+
+		if !strings.Contains(b.String(), "redis://cache.internal") || !strings.Contains(b.String(), "***") {
+			t.Fatal(format, b.String())
+		}
+	}
+
+	// values which interpolate sensitive ones are sensitive too
+	if v, ok := cfg.Get(ctx, "cache.dsn"); !ok || v != "redis://:api-token@cache.internal" {
+		t.Fatal(v, ok)
+	}
+
+	var b bytes.Buffer
+
+	if err := cfg.Export(ctx, &b, config.FormatJSON); err != nil {
+		t.Fatal(err)
+	}
+
+	var exported map[string]any
+	if err := json.Unmarshal(b.Bytes(), &exported); err != nil {
+		t.Fatal(err)
+	}
+
+	replica := exported["replica"].(map[string]any)["database"].(map[string]any)
+	if exported["cache"].(map[string]any)["dsn"] != "***" || replica["password"] != "***" || replica["host"] != "db.internal" {
+		t.Fatal(b.String())
+	}
+
+	origins, err := cfg.Explain(ctx, "database.password")
+	if err != nil || len(origins) != 2 {
+		t.Fatal(origins, err)
+	}
+
+	for _, origin := range origins {
+		secret, ok := origin.Value.(config.Secret)
+		if !ok || strings.Contains(fmt.Sprintf("%+v", origin), "-password") {
+			t.Fatal(origin)
+		}
+
+		if secret.Value() != "env-password" && secret.Value() != "default-password" {
+			t.Fatal(secret.Value())
+		}
+	}
+
+	if origins, err := cfg.Explain(ctx, "database.host"); err != nil || origins[0].Value != "db.internal" {
+		t.Fatal(origins, err)
+	}
+}
+
+func TestSecretEncoding(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory: "testdata/secret",
+		Sensitive: []string{"api.token"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	origins, err := cfg.Explain(ctx, "api.token")
+	if err != nil || len(origins) != 1 {
+		t.Fatal(origins, err)
+	}
+
+	secret := origins[0].Value
+
+	for _, s := range []string{
+		fmt.Sprint(secret),
+		fmt.Sprintf("%v %s %q %d %#v", secret, secret, secret, secret, secret),
+		fmt.Sprintf("%+v", map[string]any{"token": secret}),
+	} {
+		if strings.Contains(s, "api-token") || !strings.Contains(s, "***") {
+			t.Fatal(s)
+		}
+	}
+
+	var logs bytes.Buffer
+
+	slog.New(slog.NewJSONHandler(&logs, nil)).Info("message", "token", secret)
+
+	if strings.Contains(logs.String(), "api-token") || !strings.Contains(logs.String(), `"token":"***"`) {
+		t.Fatal(logs.String())
+	}
+
+	data := map[string]any{"token": secret}
+
+	if b, err := json.Marshal(data); err != nil || string(b) != `{"token":"***"}` {
+		t.Fatal(string(b), err)
+	}
+
+	if b, err := yaml.Marshal(data); err != nil || string(b) != "token: '***'\n" {
+		t.Fatal(string(b), err)
+	}
+
+	if b, err := toml.Marshal(data); err != nil || string(b) != "token = '***'\n" {
+		t.Fatal(string(b), err)
+	}
+}
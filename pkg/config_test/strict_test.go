@@ -0,0 +1,116 @@
+package config_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/boolka/goconfig/pkg/config"
+	"github.com/boolka/goconfig/pkg/source"
+)
+
+func TestStrict(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	_, err := config.New(ctx, config.Options{
+		Directory:       "testdata/strict",
+		Deployment:      "production",
+		InstancePattern: regexp.MustCompile(`^[a-z]{2}\d$`),
+		KnownInstances:  []string{"us1"},
+		Strict:          true,
+	})
+	if !errors.Is(err, config.ErrUnrecognizedFile) {
+		t.Fatal(err)
+	}
+
+	for _, s := range []string{
+		`prodution.yaml (unknown deployment "prodution")`,
+		`staging.toml (unknown deployment "staging")`,
+		`default-eu1.toml (unknown instance "eu1")`,
+		`default@dc=ams.toml (unknown dimension "dc")`,
+	} {
+		if !strings.Contains(err.Error(), s) {
+			t.Fatal(err)
+		}
+	}
+
+	if strings.Contains(err.Error(), "production.toml") || strings.Contains(err.Error(), "default.toml") {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory:        "testdata/strict",
+		Deployment:       "production",
+		Instance:         "eu1",
+		InstancePattern:  regexp.MustCompile(`^[a-z]{2}\d$`),
+		KnownDeployments: []string{"staging", "prodution"},
+		KnownInstances:   []string{"us1"},
+		Dimensions:       []source.Dimension{{Name: "dc", Value: "fra"}},
+		Strict:           true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, ok := cfg.Get(ctx, "source"); !ok || v != "production.toml" {
+		t.Fatal(v, ok)
+	}
+}
+
+func TestNonStrict(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	var logs bytes.Buffer
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory:  "testdata/strict",
+		Deployment: "production",
+		Logger:     slog.New(slog.NewTextHandler(&logs, nil)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, ok := cfg.Get(ctx, "source"); !ok || v != "production.toml" {
+		t.Fatal(v, ok)
+	}
+
+	// files of other deployments are expected if the valid ones are not declared
+	if strings.Contains(logs.String(), "prodution.yaml") || strings.Contains(logs.String(), "staging.toml") {
+		t.Fatal(logs.String())
+	}
+
+	// undeclared dimensions are reported anyway
+	if !strings.Contains(logs.String(), "level=WARN") || !strings.Contains(logs.String(), `default@dc=ams.toml (unknown dimension \"dc\")`) {
+		t.Fatal(logs.String())
+	}
+
+	logs.Reset()
+
+	if _, err := config.New(ctx, config.Options{
+		Directory:        "testdata/strict",
+		Deployment:       "production",
+		KnownDeployments: []string{"staging"},
+		Logger:           slog.New(slog.NewTextHandler(&logs, nil)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(logs.String(), "staging.toml") {
+		t.Fatal(logs.String())
+	}
+
+	for _, s := range []string{"prodution.yaml", "default@dc=ams.toml"} {
+		if !strings.Contains(logs.String(), "level=WARN") || !strings.Contains(logs.String(), s) {
+			t.Fatal(logs.String())
+		}
+	}
+}
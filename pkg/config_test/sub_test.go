@@ -0,0 +1,91 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/boolka/goconfig/pkg/config"
+	"github.com/boolka/goconfig/pkg/source"
+)
+
+func TestSub(t *testing.T) {
+	t.Setenv("SUB_KAFKA_TOPIC", "env-events")
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory: "testdata/sub",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kafka := cfg.Sub("kafka")
+
+	if kafka.Prefix() != "kafka" {
+		t.Fatal(kafka.Prefix())
+	}
+
+	if v, ok := kafka.Get(ctx, "topic"); !ok || v != "env-events" {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := kafka.Get(ctx, "topic", "default"); !ok || v != "events" {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := kafka.Get(ctx, "brokers.0"); !ok || v != "kafka-1:9092" {
+		t.Fatal(v, ok)
+	}
+
+	// references stay absolute
+	if v, ok := kafka.Get(ctx, "url"); !ok || v != "kafka://service" {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := kafka.Get(ctx, "name"); ok {
+		t.Fatal(v, ok)
+	}
+
+	if v, err := kafka.Lookup(ctx, "name"); !errors.Is(err, config.ErrNotFound) {
+		t.Fatal(v, err)
+	}
+
+	if v, ok := kafka.Get(ctx, ""); !ok || v.(map[string]any)["topic"] != "env-events" {
+		t.Fatal(v, ok)
+	}
+
+	consumer := kafka.Sub("consumer")
+
+	if consumer.Prefix() != "kafka.consumer" {
+		t.Fatal(consumer.Prefix())
+	}
+
+	if v := consumer.MustGet(ctx, "group"); v != "local" {
+		t.Fatal(v)
+	}
+
+	if v, err := consumer.GetDuration(ctx, "timeout"); err != nil || v != 5*time.Second {
+		t.Fatal(v, err)
+	}
+
+	if v, err := config.GetAs[[]string](ctx, kafka, "brokers"); err != nil || len(v) != 2 {
+		t.Fatal(v, err)
+	}
+
+	var dst struct {
+		Group   string        `goconfig:"group"`
+		Timeout time.Duration `goconfig:"timeout"`
+	}
+
+	if err := kafka.Bind(ctx, "consumer", &dst); err != nil || dst.Group != "local" || dst.Timeout != 5*time.Second {
+		t.Fatal(dst, err)
+	}
+
+	origins, err := consumer.Explain(ctx, "group")
+	if err != nil || len(origins) != 2 || origins[0].Type != source.LocSrc || !origins[0].Winner {
+		t.Fatal(origins, err)
+	}
+}
@@ -0,0 +1,124 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/boolka/goconfig/pkg/config"
+)
+
+func TestGetAs(t *testing.T) {
+	t.Setenv("TYPED_ENV_PORT", "9090")
+	t.Setenv("TYPED_ENV_ENABLED", "true")
+	t.Setenv("TYPED_ENV_RATIO", "0.25")
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory: "testdata/typed",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, err := config.GetAs[int](ctx, cfg, "port"); err != nil || v != 8080 {
+		t.Fatal(v, err)
+	}
+
+	if v, err := config.GetAs[uint16](ctx, cfg, "port"); err != nil || v != 8080 {
+		t.Fatal(v, err)
+	}
+
+	if v, err := config.GetAs[int64](ctx, cfg, "big"); err != nil || v != 9223372036854775807 {
+		t.Fatal(v, err)
+	}
+
+	if v, err := config.GetAs[float64](ctx, cfg, "port"); err != nil || v != 8080 {
+		t.Fatal(v, err)
+	}
+
+	if v, err := config.GetAs[float32](ctx, cfg, "ratio"); err != nil || v != 0.5 {
+		t.Fatal(v, err)
+	}
+
+	if v, err := config.GetAs[bool](ctx, cfg, "enabled"); err != nil || !v {
+		t.Fatal(v, err)
+	}
+
+	if v, err := config.GetAs[string](ctx, cfg, "name"); err != nil || v != "goconfig" {
+		t.Fatal(v, err)
+	}
+
+	if v, err := config.GetAs[string](ctx, cfg, "port"); err != nil || v != "8080" {
+		t.Fatal(v, err)
+	}
+
+	if v, err := config.GetAs[[]int](ctx, cfg, "ports"); err != nil || len(v) != 2 || v[0] != 80 || v[1] != 443 {
+		t.Fatal(v, err)
+	}
+
+	if v, err := config.GetAs[map[string]uint](ctx, cfg, "limits"); err != nil || v["read"] != 10 || v["write"] != 20 {
+		t.Fatal(v, err)
+	}
+
+	if v, err := config.GetAs[any](ctx, cfg, "name"); err != nil || v != "goconfig" {
+		t.Fatal(v, err)
+	}
+
+	t.Run("env coercion", func(t *testing.T) {
+		if v, err := config.GetAs[int](ctx, cfg, "env_port"); err != nil || v != 9090 {
+			t.Fatal(v, err)
+		}
+
+		if v, err := config.GetAs[bool](ctx, cfg, "env_enabled"); err != nil || !v {
+			t.Fatal(v, err)
+		}
+
+		if v, err := config.GetAs[float64](ctx, cfg, "env_ratio"); err != nil || v != 0.25 {
+			t.Fatal(v, err)
+		}
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		var typeErr *config.TypeError
+
+		if v, err := config.GetAs[int](ctx, cfg, "name"); !errors.Is(err, config.ErrTypeMismatch) || !errors.As(err, &typeErr) || typeErr.Path != "name" {
+			t.Fatal(v, err)
+		}
+
+		if v, err := config.GetAs[int8](ctx, cfg, "port"); !errors.Is(err, config.ErrTypeMismatch) {
+			t.Fatal(v, err)
+		}
+
+		if v, err := config.GetAs[uint](ctx, cfg, "negative"); !errors.Is(err, config.ErrTypeMismatch) {
+			t.Fatal(v, err)
+		}
+
+		if v, err := config.GetAs[int](ctx, cfg, "ratio"); !errors.Is(err, config.ErrTypeMismatch) {
+			t.Fatal(v, err)
+		}
+
+		if v, err := config.GetAs[bool](ctx, cfg, "port"); !errors.Is(err, config.ErrTypeMismatch) {
+			t.Fatal(v, err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		if v, err := config.GetAs[int](ctx, cfg, "not_exist"); !errors.Is(err, config.ErrNotFound) {
+			t.Fatal(v, err)
+		}
+
+		if v, err := config.GetAsOr(ctx, cfg, "not_exist", 42); err != nil || v != 42 {
+			t.Fatal(v, err)
+		}
+
+		if v, err := config.GetAsOr(ctx, cfg, "port", 42); err != nil || v != 8080 {
+			t.Fatal(v, err)
+		}
+
+		if v, err := config.GetAsOr(ctx, cfg, "name", 42); !errors.Is(err, config.ErrTypeMismatch) {
+			t.Fatal(v, err)
+		}
+	})
+}
@@ -0,0 +1,172 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/boolka/goconfig/pkg/config"
+)
+
+func TestTypedGetters(t *testing.T) {
+	t.Setenv("UNITS_ENV_HOSTS", "c.internal, d.internal")
+	t.Setenv("UNITS_ENV_TIMEOUT", "250ms")
+	t.Setenv("UNITS_ENV_CACHE", "2KiB")
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory: "testdata/units",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, err := cfg.GetDuration(ctx, "timeout"); err != nil || v != 90*time.Second {
+		t.Fatal(v, err)
+	}
+
+	if v, err := cfg.GetDuration(ctx, "env_timeout"); err != nil || v != 250*time.Millisecond {
+		t.Fatal(v, err)
+	}
+
+	if v, err := cfg.GetBytes(ctx, "cache"); err != nil || v != 512<<20 {
+		t.Fatal(v, err)
+	}
+
+	if v, err := cfg.GetBytes(ctx, "disk"); err != nil || v != 1_500_000_000 {
+		t.Fatal(v, err)
+	}
+
+	if v, err := cfg.GetBytes(ctx, "buffer"); err != nil || v != 4096 {
+		t.Fatal(v, err)
+	}
+
+	if v, err := cfg.GetBytes(ctx, "env_cache"); err != nil || v != 2048 {
+		t.Fatal(v, err)
+	}
+
+	if v, err := cfg.GetTime(ctx, "started_at"); err != nil || !v.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Fatal(v, err)
+	}
+
+	if v, err := cfg.GetTime(ctx, "local_at"); err != nil || !v.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.Local)) {
+		t.Fatal(v, err)
+	}
+
+	if v, err := cfg.GetTime(ctx, "day"); err != nil || !v.Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local)) {
+		t.Fatal(v, err)
+	}
+
+	if v, err := cfg.GetTime(ctx, "string_at"); err != nil || !v.Equal(time.Date(2024, 1, 2, 1, 4, 5, 0, time.UTC)) {
+		t.Fatal(v, err)
+	}
+
+	if v, err := cfg.GetURL(ctx, "endpoint"); err != nil || v.Scheme != "https" || v.Host != "api.internal:8443" || v.Path != "/v1" {
+		t.Fatal(v, err)
+	}
+
+	if v, err := cfg.GetString(ctx, "buffer"); err != nil || v != "4096" {
+		t.Fatal(v, err)
+	}
+
+	if v, err := cfg.GetStringSlice(ctx, "hosts"); err != nil || !reflect.DeepEqual(v, []string{"a.internal", "b.internal"}) {
+		t.Fatal(v, err)
+	}
+
+	if v, err := cfg.GetStringSlice(ctx, "ports"); err != nil || !reflect.DeepEqual(v, []string{"80", "443"}) {
+		t.Fatal(v, err)
+	}
+
+	if v, err := cfg.GetStringSlice(ctx, "env_hosts"); err != nil || !reflect.DeepEqual(v, []string{"c.internal", "d.internal"}) {
+		t.Fatal(v, err)
+	}
+
+	if v, err := cfg.GetStringMap(ctx, "labels"); err != nil || !reflect.DeepEqual(v, map[string]any{"team": "core", "tier": 1}) {
+		t.Fatal(v, err)
+	}
+
+	if v, err := cfg.GetStringMapString(ctx, "labels"); err != nil || !reflect.DeepEqual(v, map[string]string{"team": "core", "tier": "1"}) {
+		t.Fatal(v, err)
+	}
+}
+
+func TestTypedGettersErrors(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory: "testdata/units",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var typeErr *config.TypeError
+
+	if v, err := cfg.GetDuration(ctx, "bad_timeout"); !errors.As(err, &typeErr) || typeErr.File != "default.toml" {
+		t.Fatal(v, err)
+	}
+
+	if v, err := cfg.GetBytes(ctx, "bad_cache"); !errors.As(err, &typeErr) || typeErr.File != "local.toml" || typeErr.Path != "bad_cache" {
+		t.Fatal(v, err)
+	}
+
+	// table is merged from several sources, so the file is unknown
+	if v, err := config.GetAs[int](ctx, cfg, "labels"); !errors.As(err, &typeErr) || typeErr.File != "" {
+		t.Fatal(v, err)
+	}
+
+	var dst struct {
+		Timeout time.Duration `goconfig:"bad_timeout"`
+	}
+
+	if err := cfg.Bind(ctx, "", &dst); !errors.As(err, &typeErr) || typeErr.File != "default.toml" || typeErr.Path != "bad_timeout" {
+		t.Fatal(err)
+	}
+
+	if v, err := cfg.GetTime(ctx, "clock"); !errors.Is(err, config.ErrTypeMismatch) {
+		t.Fatal(v, err)
+	}
+
+	if v, err := cfg.GetStringMap(ctx, "hosts"); !errors.Is(err, config.ErrTypeMismatch) {
+		t.Fatal(v, err)
+	}
+
+	if v, err := cfg.GetURL(ctx, "not_exist"); !errors.Is(err, config.ErrNotFound) {
+		t.Fatal(v, err)
+	}
+}
+
+func TestBindUnits(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory: "testdata/units",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var dst struct {
+		Timeout  time.Duration   `goconfig:"timeout"`
+		Cache    config.ByteSize `goconfig:"cache"`
+		Started  time.Time       `goconfig:"started_at"`
+		Endpoint *url.URL        `goconfig:"endpoint"`
+		Hosts    []string        `goconfig:"hosts"`
+	}
+
+	if err := cfg.Bind(ctx, "", &dst); err != nil {
+		t.Fatal(err)
+	}
+
+	if dst.Timeout != 90*time.Second || dst.Cache != 512<<20 || dst.Started.IsZero() || dst.Endpoint.Host != "api.internal:8443" || len(dst.Hosts) != 2 {
+		t.Fatal(dst)
+	}
+}
@@ -0,0 +1,190 @@
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/boolka/goconfig/pkg/config"
+)
+
+// replaceFile writes data into the temporary file and renames it over the file, so
+// the watcher never reads partially written content
+func replaceFile(t *testing.T, file string, data string) {
+	t.Helper()
+
+	tmp := filepath.Join(filepath.Dir(file), "."+filepath.Base(file))
+
+	if err := os.WriteFile(tmp, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Rename(tmp, file); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "default.toml")
+
+	if err := os.WriteFile(file, []byte(`field = "initial"`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reloadErrors := make(chan error, 1)
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory:     dir,
+		Watch:         true,
+		WatchInterval: 10 * time.Millisecond,
+		OnReloadError: func(err error) {
+			select {
+			case reloadErrors <- err:
+			default:
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		cfg.Close()
+	})
+
+	changes := cfg.Subscribe(ctx, "field")
+
+	callbacks := make(chan config.Change, 1)
+	cfg.OnChange("field", func(old, new any) {
+		select {
+		case callbacks <- config.Change{Path: "field", Old: old, New: new}:
+		default:
+		}
+	})
+
+	replaceFile(t, file, `field = "changed"`)
+
+	select {
+	case change := <-changes:
+		if change.Path != "field" || change.Old != "initial" || change.New != "changed" {
+			t.Fatal(change)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("change was not received")
+	}
+
+	if change := <-callbacks; change.Old != "initial" || change.New != "changed" {
+		t.Fatal(change)
+	}
+
+	if v, ok := cfg.Get(ctx, "field"); !ok || v != "changed" {
+		t.Fatal(v, ok)
+	}
+
+	replaceFile(t, file, `field = `)
+
+	select {
+	case err := <-reloadErrors:
+		if err == nil {
+			t.Fatal("expected reload error")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("reload error was not received")
+	}
+
+	// last good state is kept
+	if v, ok := cfg.Get(ctx, "field"); !ok || v != "changed" {
+		t.Fatal(v, ok)
+	}
+
+	cancel()
+
+	if _, ok := <-changes; ok {
+		t.Fatal("channel must be closed")
+	}
+}
+
+func TestCloseWithoutWatch(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.New(context.Background(), config.Options{
+		Directory: "testdata/config",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cfg.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSubscriberDoesNotBlock(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "default.toml")
+
+	if err := os.WriteFile(file, []byte(`field = 0`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	cfg, err := config.New(ctx, config.Options{
+		Directory:     dir,
+		Watch:         true,
+		WatchInterval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// subscriber never reads
+	changes := cfg.Subscribe(ctx, "field")
+
+	for i := 1; i <= 3; i++ {
+		if err := cfg.Set(ctx, "field", i); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	replaceFile(t, file, `field = 4`)
+
+	cfg.Unset(ctx, "field")
+
+	// let the watcher reload and notify
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if v, _ := cfg.Get(ctx, "field"); v == 4 || time.Now().After(deadline) {
+			break
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	closed := make(chan struct{})
+
+	go func() {
+		cfg.Close()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close is blocked by subscriber")
+	}
+
+	// pending changes are combined
+	if change := <-changes; change.Old != 0 {
+		t.Fatal(change)
+	}
+}
@@ -1,26 +1,117 @@
 package datamap
 
 import (
-	"strings"
+	"slices"
+	"strconv"
 
 	"github.com/boolka/goconfig/pkg/normalization"
 )
 
+// Wildcard path segment matches every table key and every array element
+const Wildcard = "*"
+
+// Match is a value found by path with the concrete keys leading to it
+type Match struct {
+	Keys  []string
+	Value any
+}
+
+// GetByPath returns value by dot delimited path (see SplitPath). Arrays are indexed by numeric
+// segments, negative index counts from the end. If path contains wildcard
+// segment then the slice of all matched values is returned.
 func GetByPath(data map[string]any, path string) (any, bool) {
-	cuts := strings.Split(path, ".")
-	deep := len(cuts) - 1
+	return GetByKeys(data, SplitPath(path))
+}
 
-	var value any = data
+// GetByKeys is the same as GetByPath except that it takes already split path
+func GetByKeys(data map[string]any, keys []string) (any, bool) {
+	matches := MatchKeys(data, keys)
 
-	for i, cut := range cuts {
-		if v, ok := value.(map[string]any); ok {
-			value, ok = v[cut]
+	if HasWildcard(keys) {
+		if len(matches) == 0 {
+			return nil, false
+		}
 
-			if ok && i == deep {
-				return normalization.Number(value), true
-			}
+		values := make([]any, len(matches))
+		for i, m := range matches {
+			values[i] = m.Value
 		}
+
+		return values, true
+	}
+
+	if len(matches) == 0 {
+		return nil, false
+	}
+
+	return matches[0].Value, true
+}
+
+// HasWildcard reports if keys contain wildcard segment
+func HasWildcard(keys []string) bool {
+	return slices.Contains(keys, Wildcard)
+}
+
+// MatchKeys returns all values matched by keys in order of appearance. Table keys are walked in sorted order.
+func MatchKeys(data map[string]any, keys []string) []Match {
+	var matches []Match
+
+	match(data, keys, nil, &matches)
+
+	return matches
+}
+
+func match(value any, keys []string, walked []string, matches *[]Match) {
+	if len(keys) == 0 {
+		*matches = append(*matches, Match{
+			Keys:  slices.Clone(walked),
+			Value: normalization.Number(value),
+		})
+
+		return
 	}
 
-	return nil, false
+	key, rest := keys[0], keys[1:]
+
+	switch v := value.(type) {
+	case map[string]any:
+		if key == Wildcard {
+			mapKeys := make([]string, 0, len(v))
+			for k := range v {
+				mapKeys = append(mapKeys, k)
+			}
+			slices.Sort(mapKeys)
+
+			for _, k := range mapKeys {
+				match(v[k], rest, append(walked, k), matches)
+			}
+
+			return
+		}
+
+		if next, ok := v[key]; ok {
+			match(next, rest, append(walked, key), matches)
+		}
+	case []any:
+		if key == Wildcard {
+			for i, next := range v {
+				match(next, rest, append(walked, strconv.Itoa(i)), matches)
+			}
+
+			return
+		}
+
+		i, err := strconv.Atoi(key)
+		if err != nil {
+			return
+		}
+
+		if i < 0 {
+			i += len(v)
+		}
+
+		if i >= 0 && i < len(v) {
+			match(v[i], rest, append(walked, strconv.Itoa(i)), matches)
+		}
+	}
 }
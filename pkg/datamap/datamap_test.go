@@ -1,6 +1,7 @@
 package datamap_test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/boolka/goconfig/pkg/datamap"
@@ -59,3 +60,115 @@ func TestNilMapGetter(t *testing.T) {
 		t.Fatal(v, ok)
 	}
 }
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	high := map[string]any{
+		"field1": map[string]any{
+			"field2": int64(1),
+		},
+		"field3": "high",
+	}
+
+	low := map[string]any{
+		"field1": map[string]any{
+			"field2": 2,
+			"field4": 4,
+		},
+		"field3": map[string]any{
+			"field5": 5,
+		},
+		"field6": 6,
+	}
+
+	expected := map[string]any{
+		"field1": map[string]any{
+			"field2": 1,
+			"field4": 4,
+		},
+		"field3": "high",
+		"field6": 6,
+	}
+
+	if m := datamap.Merge(high, low); !reflect.DeepEqual(m, expected) {
+		t.Fatal(m)
+	}
+
+	if low["field1"].(map[string]any)["field2"] != 2 {
+		t.Fatal("source map modified")
+	}
+}
+
+func TestArrayIndexGetter(t *testing.T) {
+	t.Parallel()
+
+	m := map[string]any{
+		"servers": []any{
+			map[string]any{"host": "a", "port": int64(1)},
+			map[string]any{"host": "b", "port": int64(2)},
+			map[string]any{"host": "c", "port": int64(3)},
+		},
+	}
+
+	if v, ok := datamap.GetByPath(m, "servers.0.host"); !ok || v != "a" {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := datamap.GetByPath(m, "servers.1.port"); !ok || v != 2 {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := datamap.GetByPath(m, "servers.-1.host"); !ok || v != "c" {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := datamap.GetByPath(m, "servers.3.host"); ok {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := datamap.GetByPath(m, "servers.-4.host"); ok {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := datamap.GetByPath(m, "servers.first.host"); ok {
+		t.Fatal(v, ok)
+	}
+}
+
+func TestWildcardGetter(t *testing.T) {
+	t.Parallel()
+
+	m := map[string]any{
+		"servers": []any{
+			map[string]any{"host": "a", "port": int64(1)},
+			map[string]any{"port": int64(2)},
+			map[string]any{"host": "c", "port": int64(3)},
+		},
+		"limits": map[string]any{
+			"write": int64(20),
+			"read":  int64(10),
+		},
+	}
+
+	if v, ok := datamap.GetByPath(m, "servers.*.host"); !ok || !reflect.DeepEqual(v, []any{"a", "c"}) {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := datamap.GetByPath(m, "limits.*"); !ok || !reflect.DeepEqual(v, []any{10, 20}) {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := datamap.GetByPath(m, "*.*.port"); !ok || !reflect.DeepEqual(v, []any{1, 2, 3}) {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := datamap.GetByPath(m, "servers.*.user"); ok {
+		t.Fatal(v, ok)
+	}
+
+	matches := datamap.MatchKeys(m, []string{"servers", "*", "host"})
+	if len(matches) != 2 || !reflect.DeepEqual(matches[1].Keys, []string{"servers", "2", "host"}) {
+		t.Fatal(matches)
+	}
+}
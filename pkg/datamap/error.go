@@ -1,5 +1,26 @@
 package datamap
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 var ErrUnknownFileSource = errors.New("unknown file source")
+
+var ErrIncludeCycle = errors.New("include cycle")
+
+var ErrInvalidInclude = errors.New("include must be a file path or a list of file paths")
+
+// IncludeError describes failure of the included file. Chain lists files from the including one to the failed one.
+type IncludeError struct {
+	Chain []string
+	Err   error
+}
+
+func (e *IncludeError) Error() string {
+	return "include " + strings.Join(e.Chain, " -> ") + ": " + e.Err.Error()
+}
+
+func (e *IncludeError) Unwrap() error {
+	return e.Err
+}
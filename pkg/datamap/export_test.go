@@ -0,0 +1,10 @@
+package datamap
+
+// UnregisterFormat removes decoder of the file extension, so tests can restore
+// the global registry.
+func UnregisterFormat(ext string) {
+	formats.Lock()
+	defer formats.Unlock()
+
+	delete(formats.decoders, normalizeExt(ext))
+}
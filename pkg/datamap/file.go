@@ -2,20 +2,103 @@ package datamap
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"io/fs"
+	"path"
 	"path/filepath"
-
-	"github.com/pelletier/go-toml/v2"
-	"gopkg.in/yaml.v3"
+	"slices"
 )
 
-type decoder interface {
-	Decode(v any) error
-}
+// IncludeKey lists files which content is deep merged beneath the including file keys.
+// Paths are relative to the including file directory inside the same file system.
+const IncludeKey = "_include"
 
+// NewDataMapFromFile decodes the file by its extension, see RegisterFormat. Files listed by the IncludeKey
+// key are loaded recursively, the later included file overrides the earlier one and
+// keys of the including file override all of them.
 func NewDataMapFromFile(ctx context.Context, dirFs fs.ReadDirFS, fpath string) (map[string]any, error) {
+	return newDataMap(ctx, dirFs, fpath, nil)
+}
+
+func newDataMap(ctx context.Context, dirFs fs.ReadDirFS, fpath string, chain []string) (map[string]any, error) {
+	data, err := decodeFile(ctx, dirFs, fpath)
+	if err != nil {
+		return nil, err
+	}
+
+	includes, ok := data[IncludeKey]
+	if !ok {
+		return data, nil
+	}
+
+	delete(data, IncludeKey)
+
+	// paths of included files are slash separated, so is the chain
+	chain = append(slices.Clip(chain), filepath.ToSlash(fpath))
+
+	files, err := includeFiles(includes)
+	if err != nil {
+		return nil, &IncludeError{
+			Chain: chain,
+			Err:   err,
+		}
+	}
+
+	included := map[string]any{}
+
+	for _, f := range files {
+		ipath := path.Join(path.Dir(chain[len(chain)-1]), f)
+
+		if slices.Contains(chain, ipath) {
+			return nil, &IncludeError{
+				Chain: append(slices.Clip(chain), ipath),
+				Err:   ErrIncludeCycle,
+			}
+		}
+
+		idata, err := newDataMap(ctx, dirFs, ipath, chain)
+		if err != nil {
+			var includeErr *IncludeError
+			if errors.As(err, &includeErr) {
+				return nil, err
+			}
+
+			return nil, &IncludeError{
+				Chain: append(slices.Clip(chain), ipath),
+				Err:   err,
+			}
+		}
+
+		included = Merge(idata, included)
+	}
+
+	return Merge(data, included), nil
+}
+
+func includeFiles(v any) ([]string, error) {
+	switch v := v.(type) {
+	case string:
+		return []string{v}, nil
+	case []any:
+		files := make([]string, 0, len(v))
+
+		for _, f := range v {
+			s, ok := f.(string)
+			if !ok {
+				return nil, ErrInvalidInclude
+			}
+
+			files = append(files, s)
+		}
+
+		return files, nil
+	}
+
+	return nil, ErrInvalidInclude
+}
+
+func decodeFile(ctx context.Context, dirFs fs.ReadDirFS, fpath string) (map[string]any, error) {
 	f, err := dirFs.Open(fpath)
 	if err != nil {
 		return nil, err
@@ -25,36 +108,31 @@ func NewDataMapFromFile(ctx context.Context, dirFs fs.ReadDirFS, fpath string) (
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	var d decoder
-
-	switch filepath.Ext(fpath) {
-	case ".json":
-		d = json.NewDecoder(f)
-	case ".toml":
-		d = toml.NewDecoder(f)
-	case ".yaml", ".yml":
-		d = yaml.NewDecoder(f)
-	default:
+	decode, ok := formatDecoder(filepath.Ext(fpath))
+	if !ok {
 		return nil, ErrUnknownFileSource
 	}
 
-	var data map[string]any
-	done := make(chan error)
+	type decoded struct {
+		data map[string]any
+		err  error
+	}
+
+	done := make(chan decoded, 1)
 
 	go func() {
-		defer close(done)
-
-		done <- d.Decode(&data)
+		data, err := decode(f)
+		done <- decoded{data, err}
 	}()
 
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
-	case err := <-done:
-		if err != nil {
-			return nil, fmt.Errorf("decode file error: %w", err)
+	case res := <-done:
+		if res.err != nil {
+			return nil, fmt.Errorf("decode file error: %w", res.err)
 		}
-	}
 
-	return data, nil
+		return res.data, nil
+	}
 }
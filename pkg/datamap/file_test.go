@@ -0,0 +1,60 @@
+package datamap_test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/boolka/goconfig/pkg/datamap"
+)
+
+func TestInclude(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dirFs := os.DirFS("testdata").(fs.ReadDirFS)
+
+	data, err := datamap.NewDataMapFromFile(ctx, dirFs, "include/production.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(data, map[string]any{
+		"kafka": map[string]any{
+			"brokers": []any{"kafka-1:9092", "kafka-2:9092"},
+			"topic":   "production",
+			"acks":    "all",
+		},
+		"database": map[string]any{
+			"host": "shared",
+			"port": 5432,
+		},
+	}) {
+		t.Fatal(data)
+	}
+
+	var includeErr *datamap.IncludeError
+
+	_, err = datamap.NewDataMapFromFile(ctx, dirFs, "include/cycle.toml")
+	if !errors.Is(err, datamap.ErrIncludeCycle) || !errors.As(err, &includeErr) || !reflect.DeepEqual(includeErr.Chain, []string{"include/cycle.toml", "include/shared/cycle.toml", "include/cycle.toml"}) {
+		t.Fatal(err)
+	}
+
+	_, err = datamap.NewDataMapFromFile(ctx, dirFs, filepath.Join("include", "self.toml"))
+	if !errors.Is(err, datamap.ErrIncludeCycle) || !errors.As(err, &includeErr) || !reflect.DeepEqual(includeErr.Chain, []string{"include/self.toml", "include/self.toml"}) {
+		t.Fatal(err)
+	}
+
+	_, err = datamap.NewDataMapFromFile(ctx, dirFs, "include/missing.toml")
+	if !errors.Is(err, fs.ErrNotExist) || !errors.As(err, &includeErr) || !reflect.DeepEqual(includeErr.Chain, []string{"include/missing.toml", "include/shared/missing.toml"}) {
+		t.Fatal(err)
+	}
+
+	if _, err = datamap.NewDataMapFromFile(ctx, dirFs, "include/invalid.toml"); !errors.Is(err, datamap.ErrInvalidInclude) {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,85 @@
+package datamap
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// DecodeFunc decodes the content of configuration file
+type DecodeFunc func(io.Reader) (map[string]any, error)
+
+var formats = struct {
+	sync.RWMutex
+	decoders map[string]DecodeFunc
+}{
+	decoders: map[string]DecodeFunc{
+		".json": decodeJSON,
+		".toml": decodeTOML,
+		".yaml": decodeYAML,
+		".yml":  decodeYAML,
+	},
+}
+
+// RegisterFormat registers decoder of files with the extension (".ini" for example, the
+// leading dot is optional). Built in ".json", ".toml", ".yaml" and ".yml" formats can be overridden.
+// It panics if decode is nil. Formats are usually registered by init functions before loading configuration.
+func RegisterFormat(ext string, decode DecodeFunc) {
+	if decode == nil {
+		panic("datamap: RegisterFormat decode is nil")
+	}
+
+	formats.Lock()
+	defer formats.Unlock()
+
+	formats.decoders[normalizeExt(ext)] = decode
+}
+
+// HasFormat reports if decoder of files with the extension is registered
+func HasFormat(ext string) bool {
+	_, ok := formatDecoder(ext)
+
+	return ok
+}
+
+func formatDecoder(ext string) (DecodeFunc, bool) {
+	formats.RLock()
+	defer formats.RUnlock()
+
+	decode, ok := formats.decoders[normalizeExt(ext)]
+
+	return decode, ok
+}
+
+func normalizeExt(ext string) string {
+	if !strings.HasPrefix(ext, ".") {
+		return "." + ext
+	}
+
+	return ext
+}
+
+func decodeJSON(r io.Reader) (map[string]any, error) {
+	var data map[string]any
+	err := json.NewDecoder(r).Decode(&data)
+
+	return data, err
+}
+
+func decodeTOML(r io.Reader) (map[string]any, error) {
+	var data map[string]any
+	err := toml.NewDecoder(r).Decode(&data)
+
+	return data, err
+}
+
+func decodeYAML(r io.Reader) (map[string]any, error) {
+	var data map[string]any
+	err := yaml.NewDecoder(r).Decode(&data)
+
+	return data, err
+}
@@ -0,0 +1,70 @@
+package datamap_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/boolka/goconfig/pkg/datamap"
+)
+
+// decodeKV decodes "key=value" lines
+func decodeKV(r io.Reader) (map[string]any, error) {
+	data := map[string]any{}
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		if k, v, ok := strings.Cut(scanner.Text(), "="); ok {
+			data[k] = v
+		}
+	}
+
+	return data, scanner.Err()
+}
+
+func TestRegisterFormat(t *testing.T) {
+	ctx := context.Background()
+	dirFs := os.DirFS("testdata").(fs.ReadDirFS)
+
+	if datamap.HasFormat(".kv") {
+		t.Fatal("unexpected format")
+	}
+
+	if _, err := datamap.NewDataMapFromFile(ctx, dirFs, "formats/database.kv"); !errors.Is(err, datamap.ErrUnknownFileSource) {
+		t.Fatal(err)
+	}
+
+	// leading dot is optional
+	datamap.RegisterFormat("kv", decodeKV)
+	t.Cleanup(func() {
+		datamap.UnregisterFormat(".kv")
+	})
+
+	if !datamap.HasFormat(".kv") || !datamap.HasFormat("kv") || !datamap.HasFormat(".json") {
+		t.Fatal("format is not registered")
+	}
+
+	data, err := datamap.NewDataMapFromFile(ctx, dirFs, "formats/database.kv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(data, map[string]any{"host": "localhost", "port": "5432"}) {
+		t.Fatal(data)
+	}
+
+	// registered format is overridden
+	datamap.RegisterFormat(".kv", func(r io.Reader) (map[string]any, error) {
+		return nil, errors.New("broken")
+	})
+
+	if _, err := datamap.NewDataMapFromFile(ctx, dirFs, "formats/database.kv"); err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Fatal(err)
+	}
+}
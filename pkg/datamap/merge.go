@@ -0,0 +1,54 @@
+package datamap
+
+import "github.com/boolka/goconfig/pkg/normalization"
+
+// Merge returns new map with low deep merged beneath high. Values of high take
+// precedence, nested maps are merged recursively. Source maps stay untouched.
+func Merge(high, low map[string]any) map[string]any {
+	merged := Copy(low)
+
+	for k, hv := range high {
+		hm, hok := hv.(map[string]any)
+		lm, lok := merged[k].(map[string]any)
+
+		if hok && lok {
+			merged[k] = Merge(hm, lm)
+		} else {
+			merged[k] = copyValue(hv)
+		}
+	}
+
+	return merged
+}
+
+// Copy returns deep copy of data with normalized numbers
+func Copy(data map[string]any) map[string]any {
+	if data == nil {
+		return map[string]any{}
+	}
+
+	cp := make(map[string]any, len(data))
+
+	for k, v := range data {
+		cp[k] = copyValue(v)
+	}
+
+	return cp
+}
+
+func copyValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		return Copy(v)
+	case []any:
+		cp := make([]any, len(v))
+
+		for i, e := range v {
+			cp[i] = copyValue(e)
+		}
+
+		return cp
+	}
+
+	return normalization.Number(v)
+}
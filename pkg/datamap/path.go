@@ -0,0 +1,100 @@
+package datamap
+
+import "strings"
+
+// SplitPath splits path to keys. Empty path has no keys and points to the root. Keys are delimited by dots. A key that contains
+// dots may be double quoted (`domains."example.com".timeout`, backslash escapes
+// quote and backslash inside) or put in square brackets (`domains[example.com].timeout`,
+// `servers[0].host`).
+func SplitPath(path string) []string {
+	if path == "" {
+		return nil
+	}
+
+	var keys []string
+	var key strings.Builder
+
+	// key was written explicitly by quotes or brackets even if it is empty
+	var explicit bool
+
+	flush := func() {
+		keys = append(keys, key.String())
+		key.Reset()
+		explicit = false
+	}
+
+	for i := 0; i < len(path); i++ {
+		switch c := path[i]; {
+		case c == '.':
+			flush()
+		case c == '"' && key.Len() == 0 && !explicit:
+			for i++; i < len(path) && path[i] != '"'; i++ {
+				if path[i] == '\\' && i+1 < len(path) {
+					i++
+				}
+
+				key.WriteByte(path[i])
+			}
+
+			explicit = true
+		case c == '[':
+			if key.Len() > 0 || explicit {
+				flush()
+			}
+
+			end := strings.IndexByte(path[i+1:], ']')
+			if end < 0 {
+				key.WriteString(path[i+1:])
+				i = len(path)
+			} else {
+				key.WriteString(path[i+1 : i+1+end])
+				i += end + 1
+			}
+
+			explicit = true
+
+			// bracket key is delimited by itself, skip following dot
+			if i+1 < len(path) && path[i+1] == '.' {
+				i++
+				flush()
+			} else if i+1 < len(path) && path[i+1] != '[' {
+				flush()
+			}
+		default:
+			key.WriteByte(c)
+		}
+	}
+
+	flush()
+
+	return keys
+}
+
+// JoinPath joins keys to path. Keys that contain special characters are quoted.
+func JoinPath(keys []string) string {
+	var path strings.Builder
+
+	for i, key := range keys {
+		if i > 0 {
+			path.WriteByte('.')
+		}
+
+		if key == "" || strings.ContainsAny(key, `."[]\`) {
+			path.WriteByte('"')
+
+			for _, c := range []byte(key) {
+				if c == '"' || c == '\\' {
+					path.WriteByte('\\')
+				}
+
+				path.WriteByte(c)
+			}
+
+			path.WriteByte('"')
+		} else {
+			path.WriteString(key)
+		}
+	}
+
+	return path.String()
+}
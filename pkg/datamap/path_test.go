@@ -0,0 +1,81 @@
+package datamap_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/boolka/goconfig/pkg/datamap"
+)
+
+func TestSplitPath(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string][]string{
+		"":                                nil,
+		"field":                           {"field"},
+		"field1.field2":                   {"field1", "field2"},
+		`domains."example.com".timeout`:   {"domains", "example.com", "timeout"},
+		`domains."example.com"`:           {"domains", "example.com"},
+		`"a\"b\\c"`:                       {`a"b\c`},
+		`""`:                              {""},
+		"domains[example.com].timeout":    {"domains", "example.com", "timeout"},
+		"servers[0][1]":                   {"servers", "0", "1"},
+		"[example.com]":                   {"example.com"},
+		"servers.[0].host":                {"servers", "0", "host"},
+		`domains["example.com"]`:          {"domains", `"example.com"`},
+		`domains."example.com"[0].weight`: {"domains", "example.com", "0", "weight"},
+	}
+
+	for path, expected := range testCases {
+		if keys := datamap.SplitPath(path); !reflect.DeepEqual(keys, expected) {
+			t.Error(path, keys)
+		}
+	}
+}
+
+func TestJoinPath(t *testing.T) {
+	t.Parallel()
+
+	testCases := [][]string{
+		nil,
+		{""},
+		{"field"},
+		{"field1", "field2"},
+		{"domains", "example.com", "timeout"},
+		{`a"b\c`, "[0]", ""},
+	}
+
+	for _, keys := range testCases {
+		if split := datamap.SplitPath(datamap.JoinPath(keys)); !reflect.DeepEqual(split, keys) {
+			t.Error(keys, split)
+		}
+	}
+
+	if path := datamap.JoinPath([]string{"domains", "example.com", "timeout"}); path != `domains."example.com".timeout` {
+		t.Fatal(path)
+	}
+}
+
+func TestDottedKeyGetter(t *testing.T) {
+	t.Parallel()
+
+	m := map[string]any{
+		"domains": map[string]any{
+			"example.com": map[string]any{
+				"timeout": 1,
+			},
+		},
+	}
+
+	if v, ok := datamap.GetByPath(m, `domains."example.com".timeout`); !ok || v != 1 {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := datamap.GetByPath(m, "domains[example.com].timeout"); !ok || v != 1 {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := datamap.GetByPath(m, "domains.example.com.timeout"); ok {
+		t.Fatal(v, ok)
+	}
+}
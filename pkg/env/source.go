@@ -23,16 +23,83 @@ func NewEnvSource(ctx context.Context, dirFs fs.ReadDirFS, fpath string) (*EnvSo
 	}, nil
 }
 
+// Get looks up environment variable which name is stored by path. If path
+// points to a table or an array then all set environment variables beneath are returned.
+// Wildcard path returns the slice of all set environment variables matched.
 func (s *EnvSource) Get(_ context.Context, path string) (any, bool) {
-	v, ok := datamap.GetByPath(s.data, path)
-	if !ok {
-		return nil, false
+	keys := datamap.SplitPath(path)
+	matches := datamap.MatchKeys(s.data, keys)
+
+	if !datamap.HasWildcard(keys) {
+		if len(matches) == 0 {
+			return nil, false
+		}
+
+		return lookup(matches[0].Value)
+	}
+
+	var values []any
+
+	for _, m := range matches {
+		if v, ok := lookup(m.Value); ok {
+			values = append(values, v)
+		}
 	}
 
-	vString, ok := v.(string)
+	return values, len(values) > 0
+}
+
+func lookup(v any) (any, bool) {
+	switch v := v.(type) {
+	case string:
+		return os.LookupEnv(v)
+	case map[string]any:
+		resolved := resolve(v)
+
+		return resolved, len(resolved) > 0
+	case []any:
+		resolved := resolveArray(v)
+
+		return resolved, len(resolved) > 0
+	}
+
+	return nil, false
+}
+
+// resolve environment variable names of the table, unset variables are omitted
+func resolve(data map[string]any) map[string]any {
+	resolved := map[string]any{}
+
+	for k, v := range data {
+		if env, ok := lookup(v); ok {
+			resolved[k] = env
+		}
+	}
+
+	return resolved
+}
+
+// resolve environment variable names of the array, unset variables are omitted
+func resolveArray(data []any) []any {
+	var resolved []any
+
+	for _, v := range data {
+		if env, ok := lookup(v); ok {
+			resolved = append(resolved, env)
+		}
+	}
+
+	return resolved
+}
+
+// Reference returns environment variable name stored by path
+func (s *EnvSource) Reference(_ context.Context, path string) (string, bool) {
+	v, ok := datamap.GetByPath(s.data, path)
 	if !ok {
-		return nil, false
+		return "", false
 	}
 
-	return os.LookupEnv(vString)
+	name, ok := v.(string)
+
+	return name, ok
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"io/fs"
 	"os"
+	"reflect"
 	"testing"
 
 	envEntry "github.com/boolka/goconfig/pkg/env"
@@ -51,3 +52,92 @@ func TestEnvSource(t *testing.T) {
 		t.Fatal(v, ok)
 	}
 }
+
+func TestEnvSourceTable(t *testing.T) {
+	ctx := context.Background()
+
+	envSource, err := envEntry.NewEnvSource(ctx, os.DirFS("testdata").(fs.ReadDirFS), "env.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, ok := envSource.Get(ctx, "obj"); ok {
+		t.Fatal(v, ok)
+	}
+
+	t.Setenv("CUSTOM_ENV_1", "variable4321")
+
+	if v, ok := envSource.Get(ctx, "obj"); !ok || !reflect.DeepEqual(v, map[string]any{"custom": "variable4321"}) {
+		t.Fatal(v, ok)
+	}
+}
+
+func TestEnvSourceWildcard(t *testing.T) {
+	ctx := context.Background()
+
+	envSource, err := envEntry.NewEnvSource(ctx, os.DirFS("testdata").(fs.ReadDirFS), "env.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, ok := envSource.Get(ctx, "list.*.custom"); ok {
+		t.Fatal(v, ok)
+	}
+
+	t.Setenv("CUSTOM_ENV_LIST_1", "variable1")
+
+	if v, ok := envSource.Get(ctx, "list.*.custom"); !ok || !reflect.DeepEqual(v, []any{"variable1"}) {
+		t.Fatal(v, ok)
+	}
+
+	t.Setenv("CUSTOM_ENV_LIST_0", "variable0")
+
+	if v, ok := envSource.Get(ctx, "list.*.custom"); !ok || !reflect.DeepEqual(v, []any{"variable0", "variable1"}) {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := envSource.Get(ctx, "list.-1.custom"); !ok || v != "variable1" {
+		t.Fatal(v, ok)
+	}
+}
+
+func TestEnvSourceArray(t *testing.T) {
+	ctx := context.Background()
+
+	envSource, err := envEntry.NewEnvSource(ctx, os.DirFS("testdata").(fs.ReadDirFS), "env.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, ok := envSource.Get(ctx, "hosts"); ok {
+		t.Fatal(v, ok)
+	}
+
+	t.Setenv("CUSTOM_ENV_HOST_1", "host1")
+
+	// unset variables are omitted
+	if v, ok := envSource.Get(ctx, "hosts"); !ok || !reflect.DeepEqual(v, []any{"host1"}) {
+		t.Fatal(v, ok)
+	}
+
+	t.Setenv("CUSTOM_ENV_HOST_0", "host0")
+
+	if v, ok := envSource.Get(ctx, "hosts"); !ok || !reflect.DeepEqual(v, []any{"host0", "host1"}) {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := envSource.Get(ctx, "hosts.1"); !ok || v != "host1" {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := envSource.Get(ctx, "db"); !ok || !reflect.DeepEqual(v, map[string]any{"list": []any{"host0"}}) {
+		t.Fatal(v, ok)
+	}
+
+	t.Setenv("CUSTOM_ENV_LIST_1", "variable1")
+
+	// arrays of tables are resolved too
+	if v, ok := envSource.Get(ctx, "list"); !ok || !reflect.DeepEqual(v, []any{map[string]any{"custom": "variable1"}}) {
+		t.Fatal(v, ok)
+	}
+}
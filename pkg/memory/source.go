@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/boolka/goconfig/pkg/datamap"
+)
+
+// MemorySource holds values set at runtime. It is immutable: Set and Unset
+// return modified copy, so it can be read concurrently without locks.
+type MemorySource struct {
+	data map[string]any
+}
+
+func NewMemorySource() *MemorySource {
+	return &MemorySource{
+		data: map[string]any{},
+	}
+}
+
+// Get returns the value set by path. Empty root table is not a value, so
+// the source does not shadow other sources until something is set.
+func (s *MemorySource) Get(_ context.Context, path string) (any, bool) {
+	if len(s.data) == 0 {
+		return nil, false
+	}
+
+	return datamap.GetByPath(s.data, path)
+}
+
+// Set returns copy of the source with value stored by keys. Missing tables are
+// created and scalars on the way are replaced with tables.
+func (s *MemorySource) Set(keys []string, value any) *MemorySource {
+	data := datamap.Copy(s.data)
+	m := data
+
+	for _, k := range keys[:len(keys)-1] {
+		next, ok := m[k].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			m[k] = next
+		}
+
+		m = next
+	}
+
+	if v, ok := value.(map[string]any); ok {
+		value = datamap.Copy(v)
+	}
+
+	m[keys[len(keys)-1]] = value
+
+	return &MemorySource{
+		data: data,
+	}
+}
+
+// Unset returns copy of the source without the value stored by keys. Tables left
+// empty are removed too, so they do not shadow values of lower sources.
+func (s *MemorySource) Unset(keys []string) *MemorySource {
+	data := datamap.Copy(s.data)
+
+	unset(data, keys)
+
+	return &MemorySource{
+		data: data,
+	}
+}
+
+func unset(m map[string]any, keys []string) {
+	if len(keys) == 1 {
+		delete(m, keys[0])
+		return
+	}
+
+	next, ok := m[keys[0]].(map[string]any)
+	if !ok {
+		return
+	}
+
+	unset(next, keys[1:])
+
+	if len(next) == 0 {
+		delete(m, keys[0])
+	}
+}
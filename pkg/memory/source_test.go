@@ -0,0 +1,66 @@
+package memory_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/boolka/goconfig/pkg/memory"
+)
+
+func TestMemorySource(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	empty := memory.NewMemorySource()
+
+	if v, ok := empty.Get(ctx, "a"); ok {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := empty.Get(ctx, ""); ok {
+		t.Fatal(v, ok)
+	}
+
+	src := empty.Set([]string{"a", "b"}, 1).Set([]string{"a", "c"}, "str")
+
+	if v, ok := empty.Get(ctx, "a.b"); ok {
+		t.Fatal("source is modified", v)
+	}
+
+	if v, ok := src.Get(ctx, "a"); !ok || !reflect.DeepEqual(v, map[string]any{"b": 1, "c": "str"}) {
+		t.Fatal(v, ok)
+	}
+
+	// scalar is replaced with table
+	src = src.Set([]string{"a", "b", "c"}, true)
+
+	if v, ok := src.Get(ctx, "a.b.c"); !ok || v != true {
+		t.Fatal(v, ok)
+	}
+
+	src = src.Unset([]string{"a", "b", "c"}).Unset([]string{"a", "c"})
+
+	// empty tables are removed
+	if v, ok := src.Get(ctx, "a"); ok {
+		t.Fatal(v, ok)
+	}
+
+	if v, ok := src.Get(ctx, ""); ok {
+		t.Fatal(v, ok)
+	}
+
+	src = src.Set([]string{"key.with.dots"}, 1.5)
+
+	if v, ok := src.Get(ctx, `"key.with.dots"`); !ok || v != 1.5 {
+		t.Fatal(v, ok)
+	}
+
+	// unset of missing path is not a failure
+	src = src.Unset([]string{"not", "exist"})
+
+	if v, ok := src.Get(ctx, `"key.with.dots"`); !ok || v != 1.5 {
+		t.Fatal(v, ok)
+	}
+}
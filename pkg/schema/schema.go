@@ -0,0 +1,286 @@
+package schema
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/boolka/goconfig/pkg/datamap"
+	"github.com/boolka/goconfig/pkg/normalization"
+)
+
+// Schema describes expected configuration structure. It is a subset of [JSON Schema]
+// and can be declared in go or parsed from JSON document.
+//
+//   - Type: one of "object", "array", "string", "integer", "number", "boolean" or "null". String values
+//     are parsed into integers, numbers and booleans if such type is expected (environment variables
+//     are strings always), the same way config.GetAs converts them
+//
+//   - Properties, Required, AdditionalProperties: object keys schemas, required keys and whether unknown keys are allowed
+//
+//   - Items, MinItems, MaxItems: array elements schema and length bounds
+//
+//   - Enum: list of allowed values
+//
+//   - Minimum, Maximum: inclusive number bounds
+//
+//   - MinLength, MaxLength, Pattern: string length bounds and regular expression
+//
+// [JSON Schema]: https://json-schema.org
+type Schema struct {
+	Type                 string             `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+}
+
+// Violation is a single schema constraint violated by the value at path
+type Violation struct {
+	Path    string
+	Message string
+}
+
+func (v Violation) String() string {
+	if v.Path == "" {
+		return v.Message
+	}
+
+	return v.Path + ": " + v.Message
+}
+
+// ValidationError lists all the violations found by validation
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Violations))
+
+	for i, v := range e.Violations {
+		msgs[i] = v.String()
+	}
+
+	return "schema validation failed: " + strings.Join(msgs, "; ")
+}
+
+// Parse decodes JSON schema document
+func Parse(r io.Reader) (*Schema, error) {
+	var s Schema
+
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, fmt.Errorf("decode schema error: %w", err)
+	}
+
+	return &s, nil
+}
+
+// Load reads JSON schema document from the file system
+func Load(fsys fs.FS, name string) (*Schema, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Parse(f)
+}
+
+// Validate checks value against the schema. It returns nil if the value is valid.
+func (s *Schema) Validate(v any) error {
+	var violations []Violation
+
+	s.validate(v, nil, &violations)
+
+	if len(violations) > 0 {
+		return &ValidationError{
+			Violations: violations,
+		}
+	}
+
+	return nil
+}
+
+func (s *Schema) validate(v any, keys []string, violations *[]Violation) {
+	report := func(format string, args ...any) {
+		*violations = append(*violations, Violation{
+			Path:    datamap.JoinPath(keys),
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	v = parse(normalization.Number(v), s.Type)
+
+	if s.Type != "" && !isType(v, s.Type) {
+		report("expected %s, got %T", s.Type, v)
+		return
+	}
+
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(e any) bool {
+		return reflect.DeepEqual(normalization.Number(e), v)
+	}) {
+		report("value %v is not one of %v", v, s.Enum)
+	}
+
+	if f, ok := number(v); ok {
+		if s.Minimum != nil && f < *s.Minimum {
+			report("value %v is less than minimum %v", v, *s.Minimum)
+		}
+
+		if s.Maximum != nil && f > *s.Maximum {
+			report("value %v is greater than maximum %v", v, *s.Maximum)
+		}
+	}
+
+	switch v := v.(type) {
+	case string:
+		length := utf8.RuneCountInString(v)
+
+		if s.MinLength != nil && length < *s.MinLength {
+			report("length %d is less than %d", length, *s.MinLength)
+		}
+
+		if s.MaxLength != nil && length > *s.MaxLength {
+			report("length %d is greater than %d", length, *s.MaxLength)
+		}
+
+		if s.Pattern != "" {
+			re, err := regexp.Compile(s.Pattern)
+			if err != nil {
+				report("invalid pattern %q: %s", s.Pattern, err.Error())
+			} else if !re.MatchString(v) {
+				report("value %q does not match pattern %q", v, s.Pattern)
+			}
+		}
+	case []any:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			report("%d items is less than %d", len(v), *s.MinItems)
+		}
+
+		if s.MaxItems != nil && len(v) > *s.MaxItems {
+			report("%d items is greater than %d", len(v), *s.MaxItems)
+		}
+
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(item, append(slices.Clip(keys), fmt.Sprint(i)), violations)
+			}
+		}
+	case map[string]any:
+		for _, key := range s.Required {
+			if _, ok := v[key]; !ok {
+				*violations = append(*violations, Violation{
+					Path:    datamap.JoinPath(append(slices.Clip(keys), key)),
+					Message: "required key is missing",
+				})
+			}
+		}
+
+		mapKeys := make([]string, 0, len(v))
+		for k := range v {
+			mapKeys = append(mapKeys, k)
+		}
+		slices.Sort(mapKeys)
+
+		for _, k := range mapKeys {
+			if prop, ok := s.Properties[k]; ok {
+				prop.validate(v[k], append(slices.Clip(keys), k), violations)
+			} else if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				*violations = append(*violations, Violation{
+					Path:    datamap.JoinPath(append(slices.Clip(keys), k)),
+					Message: "unknown key",
+				})
+			}
+		}
+	}
+}
+
+func isType(v any, t string) bool {
+	switch t {
+	case "object":
+		_, ok := v.(map[string]any)
+		return ok
+	case "array":
+		_, ok := v.([]any)
+		return ok
+	case "string":
+		switch v.(type) {
+		case string, encoding.TextMarshaler:
+			// dates and times are strings in JSON terms
+			return true
+		}
+	case "integer":
+		switch v.(type) {
+		case int, uint:
+			return true
+		}
+	case "number":
+		_, ok := number(v)
+		return ok
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "null":
+		return v == nil
+	}
+
+	return false
+}
+
+func number(v any) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	}
+
+	return 0, false
+}
+
+// parse string value into the expected scalar type, the value is kept if it can not be parsed
+func parse(v any, t string) any {
+	str, ok := v.(string)
+	if !ok {
+		return v
+	}
+
+	str = strings.TrimSpace(str)
+
+	switch t {
+	case "integer":
+		if i, err := strconv.ParseInt(str, 0, 64); err == nil {
+			return int(i)
+		}
+	case "number":
+		if f, err := strconv.ParseFloat(str, 64); err == nil {
+			return f
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(str); err == nil {
+			return b
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,140 @@
+package schema_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boolka/goconfig/pkg/schema"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func violations(t *testing.T, err error) []schema.Violation {
+	t.Helper()
+
+	if err == nil {
+		return nil
+	}
+
+	var validationErr *schema.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatal(err)
+	}
+
+	return validationErr.Violations
+}
+
+func TestValidateTypes(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		typ   string
+		value any
+		valid bool
+	}{
+		{"object", map[string]any{}, true},
+		{"object", []any{}, false},
+		{"array", []any{}, true},
+		{"string", "value", true},
+		{"string", time.Now(), true},
+		{"string", 1, false},
+		{"integer", 1, true},
+		{"integer", int64(1), true},
+		{"integer", 1.5, false},
+		{"number", 1.5, true},
+		{"number", uint(1), true},
+		{"number", "1", true},
+		{"number", "one", false},
+		{"integer", " 0x10 ", true},
+		{"integer", "1.5", false},
+		{"boolean", "true", true},
+		{"boolean", "yes", false},
+		{"boolean", true, true},
+		{"null", nil, true},
+		{"null", 0, false},
+	}
+
+	for _, testCase := range testCases {
+		s := schema.Schema{Type: testCase.typ}
+
+		if err := s.Validate(testCase.value); (err == nil) != testCase.valid {
+			t.Error(testCase.typ, testCase.value, err)
+		}
+	}
+}
+
+func TestValidateConstraints(t *testing.T) {
+	t.Parallel()
+
+	s := schema.Schema{
+		Type:     "object",
+		Required: []string{"name", "ports", "missing"},
+		Properties: map[string]*schema.Schema{
+			"name": {
+				Type:      "string",
+				MinLength: ptr(5),
+				Pattern:   "^[a-z]+$",
+			},
+			"ports": {
+				Type:     "array",
+				MaxItems: ptr(2),
+				Items: &schema.Schema{
+					Type:    "integer",
+					Minimum: ptr(1.0),
+				},
+			},
+			"mode": {
+				Enum: []any{"a", "b", float64(1)},
+			},
+		},
+	}
+
+	v := violations(t, s.Validate(map[string]any{
+		"name":  "A1",
+		"ports": []any{0, 1, 2},
+		"mode":  1,
+	}))
+
+	expected := []string{
+		"missing: required key is missing",
+		`name: length 2 is less than 5`,
+		`name: value "A1" does not match pattern "^[a-z]+$"`,
+		"ports: 3 items is greater than 2",
+		"ports.0: value 0 is less than minimum 1",
+	}
+
+	if len(v) != len(expected) {
+		t.Fatal(v)
+	}
+
+	for i := range v {
+		if v[i].String() != expected[i] {
+			t.Error(i, v[i])
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	s, err := schema.Parse(strings.NewReader(`{"type": "object", "properties": {"port": {"type": "integer", "enum": [80, 443]}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Validate(map[string]any{"port": 443}); err != nil {
+		t.Fatal(err)
+	}
+
+	if v := violations(t, s.Validate(map[string]any{"port": 8080})); len(v) != 1 || v[0].Path != "port" {
+		t.Fatal(v)
+	}
+
+	if _, err := schema.Parse(strings.NewReader(`{"type": 1}`)); err == nil {
+		t.Fatal("expected decode error")
+	}
+}
@@ -0,0 +1,34 @@
+package source
+
+import "strings"
+
+// Dimension is a named configuration axis like datacenter, zone or tenant
+type Dimension struct {
+	Name  string
+	Value string
+}
+
+// SplitDimensions splits filename (without extension) of "{base}@{name}={value}@..."
+// form into base and dimension qualifiers. Filename with malformed qualifier is returned as is.
+func SplitDimensions(filename string) (string, []Dimension) {
+	base, qualifiers, ok := strings.Cut(filename, "@")
+	if !ok {
+		return filename, nil
+	}
+
+	var dims []Dimension
+
+	for _, q := range strings.Split(qualifiers, "@") {
+		name, value, ok := strings.Cut(q, "=")
+		if !ok || name == "" || value == "" {
+			return filename, nil
+		}
+
+		dims = append(dims, Dimension{
+			Name:  name,
+			Value: value,
+		})
+	}
+
+	return base, dims
+}
@@ -0,0 +1,72 @@
+package source
+
+import (
+	"context"
+
+	"github.com/boolka/goconfig/pkg/datamap"
+)
+
+type namespaced struct {
+	Originer
+	namespace string
+}
+
+type namespacedReferencer struct {
+	namespaced
+	referencer Referencer
+}
+
+// Namespace returns originer which values are nested beneath the namespace key.
+// Referencer implementation of the originer is kept.
+func Namespace(o Originer, namespace string) Originer {
+	n := namespaced{
+		Originer:  o,
+		namespace: namespace,
+	}
+
+	if r, ok := o.(Referencer); ok {
+		return &namespacedReferencer{
+			namespaced: n,
+			referencer: r,
+		}
+	}
+
+	return &n
+}
+
+func (n *namespaced) Get(ctx context.Context, path string) (any, bool) {
+	keys := datamap.SplitPath(path)
+
+	if len(keys) == 0 {
+		v, ok := n.Originer.Get(ctx, "")
+		if !ok {
+			return v, false
+		}
+
+		return map[string]any{n.namespace: v}, true
+	}
+
+	if keys[0] != n.namespace && keys[0] != datamap.Wildcard {
+		return nil, false
+	}
+
+	rest := keys[1:]
+
+	v, ok := n.Originer.Get(ctx, datamap.JoinPath(rest))
+	if !ok || keys[0] != datamap.Wildcard || datamap.HasWildcard(rest) {
+		return v, ok
+	}
+
+	// the only namespace key is matched by the wildcard
+	return []any{v}, true
+}
+
+func (n *namespacedReferencer) Reference(ctx context.Context, path string) (string, bool) {
+	keys := datamap.SplitPath(path)
+
+	if len(keys) == 0 || keys[0] != n.namespace {
+		return "", false
+	}
+
+	return n.referencer.Reference(ctx, datamap.JoinPath(keys[1:]))
+}
@@ -2,62 +2,127 @@ package source
 
 import (
 	"regexp"
+	"slices"
 	"strings"
 )
 
-var reDefInst = regexp.MustCompile(`^default-\d+$`)
-var reLocInst = regexp.MustCompile(`^local-\d+$`)
-var reLocDep = regexp.MustCompile(`^local-\w+$`)
-var reLocDepInst = regexp.MustCompile(`^local-\w+-\d+$`)
+var reWord = regexp.MustCompile(`^\w+$`)
 
-var reInst = regexp.MustCompile(`^(\d+)$`)
-var reWordInst = regexp.MustCompile(`^(.+)-(\d+)$`)
+// DefaultInstancePattern matches numeric instance identifiers
+var DefaultInstancePattern = regexp.MustCompile(`^\d+$`)
+
+// Parser recognizes source type, deployment and instance by the filename
+// (without extension). Filenames like "production-blue" are ambiguous, they are
+// resolved deterministically:
+//
+//   - whole name listed in Deployments is a deployment
+//
+//   - token listed in Instances is an instance, token listed in Deployments is not
+//
+//   - otherwise token is an instance if it matches InstancePattern (DefaultInstancePattern if nil)
+//
+// Deployment and instance are split by the last dash, unless the left part is listed in Deployments.
+type Parser struct {
+	Hostname        string
+	Deployments     []string
+	Instances       []string
+	InstancePattern *regexp.Regexp
+}
 
 // returns source type, deployment, instance
 func ParseFilename(filename, hostname string) (SourceType, string, string) {
-	if filename == hostname {
+	return Parser{Hostname: hostname}.Parse(filename)
+}
+
+// Parse returns source type, deployment and instance of the filename
+func (p Parser) Parse(filename string) (SourceType, string, string) {
+	if filename == p.Hostname {
 		return HostSrc, "", ""
 	}
 
-	fileSplit := strings.Split(filename, "-")
-
-	switch {
-	case filename == "env":
+	switch filename {
+	case "env":
 		return EnvSrc, "", ""
-	case filename == "vault":
+	case "vault":
 		return VaultSrc, "", ""
-	case filename == "default":
+	case "default":
 		return DefSrc, "", ""
-	case reDefInst.MatchString(filename):
-		return DefInstSrc, "", fileSplit[1]
-	case filename == "local":
+	case "local":
 		return LocSrc, "", ""
-	case reLocInst.MatchString(filename):
-		return LocInstSrc, "", fileSplit[1]
-	case reLocDep.MatchString(filename):
-		return LocDepSrc, fileSplit[1], ""
-	case reLocDepInst.MatchString(filename):
-		return LocDepInstSrc, fileSplit[1], fileSplit[2]
-	case strings.Contains(filename, hostname) && hostname != "":
-		filename = strings.TrimPrefix(filename, hostname+"-")
-
-		switch {
-		case reInst.MatchString(filename):
-			r := reInst.FindStringSubmatch(filename)
-
-			return HostInstSrc, "", r[1]
-		case reWordInst.MatchString(filename):
-			r := reWordInst.FindStringSubmatch(filename)
-
-			return HostDepInstSrc, r[1], r[2]
-		default:
-			return HostDepSrc, filename, ""
+	}
+
+	if rest, ok := strings.CutPrefix(filename, "default-"); ok && p.isInstance(rest) {
+		return DefInstSrc, "", rest
+	}
+
+	if rest, ok := strings.CutPrefix(filename, "local-"); ok {
+		if p.isInstance(rest) {
+			return LocInstSrc, "", rest
+		}
+
+		if reWord.MatchString(rest) {
+			return LocDepSrc, rest, ""
+		}
+
+		if d, i, ok := p.split(rest); ok && reWord.MatchString(d) {
+			return LocDepInstSrc, d, i
+		}
+	}
+
+	if p.Hostname != "" {
+		if rest, ok := strings.CutPrefix(filename, p.Hostname+"-"); ok {
+			if p.isInstance(rest) {
+				return HostInstSrc, "", rest
+			}
+
+			if d, i, ok := p.split(rest); ok {
+				return HostDepInstSrc, d, i
+			}
+
+			return HostDepSrc, rest, ""
 		}
-	case reWordInst.MatchString(filename):
-		r := reWordInst.FindStringSubmatch(filename)
+	}
 
-		return DepInstSrc, r[1], r[2]
+	if d, i, ok := p.split(filename); ok {
+		return DepInstSrc, d, i
 	}
 
 	return DepSrc, filename, ""
 }
+
+func (p Parser) isInstance(s string) bool {
+	if slices.Contains(p.Instances, s) {
+		return true
+	}
+
+	if slices.Contains(p.Deployments, s) {
+		return false
+	}
+
+	pattern := p.InstancePattern
+	if pattern == nil {
+		pattern = DefaultInstancePattern
+	}
+
+	return pattern.MatchString(s)
+}
+
+// split name into deployment and instance
+func (p Parser) split(name string) (string, string, bool) {
+	if slices.Contains(p.Deployments, name) {
+		return "", "", false
+	}
+
+	for _, d := range p.Deployments {
+		if i, ok := strings.CutPrefix(name, d+"-"); ok && p.isInstance(i) {
+			return d, i, true
+		}
+	}
+
+	dash := strings.LastIndexByte(name, '-')
+	if dash <= 0 || !p.isInstance(name[dash+1:]) {
+		return "", "", false
+	}
+
+	return name[:dash], name[dash+1:], true
+}
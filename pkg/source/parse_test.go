@@ -1,6 +1,7 @@
 package source_test
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/boolka/goconfig/pkg/source"
@@ -73,3 +74,73 @@ func TestParseFilenameToSourceType(t *testing.T) {
 		t.Fatal("unexpected-source-1-json")
 	}
 }
+
+func TestParserNamedInstances(t *testing.T) {
+	t.Parallel()
+
+	named := source.Parser{
+		Hostname:        "hostname",
+		InstancePattern: regexp.MustCompile(`^[a-z]+\d*$`),
+	}
+
+	for filename, want := range map[string]struct {
+		srcType    source.SourceType
+		deployment string
+		instance   string
+	}{
+		"default-eu1":                {source.DefInstSrc, "", "eu1"},
+		"local-blue":                 {source.LocInstSrc, "", "blue"},
+		"local-production-blue":      {source.LocDepInstSrc, "production", "blue"},
+		"production-blue":            {source.DepInstSrc, "production", "blue"},
+		"production":                 {source.DepSrc, "production", ""},
+		"hostname-eu1":               {source.HostInstSrc, "", "eu1"},
+		"hostname-production-blue":   {source.HostDepInstSrc, "production", "blue"},
+		"eu-west-production-blue":    {source.DepInstSrc, "eu-west-production", "blue"},
+		"eu-west-production-blue-10": {source.DepSrc, "eu-west-production-blue-10", ""},
+	} {
+		if s, d, i := named.Parse(filename); s != want.srcType || d != want.deployment || i != want.instance {
+			t.Fatal(filename, s, d, i)
+		}
+	}
+
+	// known deployments and instances resolve ambiguity
+	known := source.Parser{
+		Deployments: []string{"blue", "eu-west"},
+		Instances:   []string{"canary"},
+	}
+
+	for filename, want := range map[string]struct {
+		srcType    source.SourceType
+		deployment string
+		instance   string
+	}{
+		"default-canary":    {source.DefInstSrc, "", "canary"},
+		"default-blue":      {source.DepSrc, "default-blue", ""},
+		"local-blue":        {source.LocDepSrc, "blue", ""},
+		"blue-canary":       {source.DepInstSrc, "blue", "canary"},
+		"eu-west":           {source.DepSrc, "eu-west", ""},
+		"eu-west-1":         {source.DepInstSrc, "eu-west", "1"},
+		"production-canary": {source.DepInstSrc, "production", "canary"},
+		"production-blue":   {source.DepSrc, "production-blue", ""},
+	} {
+		if s, d, i := known.Parse(filename); s != want.srcType || d != want.deployment || i != want.instance {
+			t.Fatal(filename, s, d, i)
+		}
+	}
+}
+
+func TestSplitDimensions(t *testing.T) {
+	t.Parallel()
+
+	if base, dims := source.SplitDimensions("default"); base != "default" || dims != nil {
+		t.Fatal(base, dims)
+	}
+
+	if base, dims := source.SplitDimensions("production-1@dc=ams@zone=ams-1"); base != "production-1" || len(dims) != 2 || dims[0] != (source.Dimension{Name: "dc", Value: "ams"}) || dims[1] != (source.Dimension{Name: "zone", Value: "ams-1"}) {
+		t.Fatal(base, dims)
+	}
+
+	if base, dims := source.SplitDimensions("default@dc"); base != "default@dc" || dims != nil {
+		t.Fatal(base, dims)
+	}
+}
@@ -7,10 +7,22 @@ import (
 	"github.com/boolka/goconfig/pkg/file"
 )
 
+// Originer provides values of the source by path. Failure (unavailable storage and etc.)
+// is reported by returning the error as the value with false.
 type Originer interface {
 	Get(context.Context, string) (any, bool)
 }
 
+// Constructor creates originer of the configuration file
+type Constructor func(ctx context.Context, dirFs fs.ReadDirFS, fpath string) (Originer, error)
+
+// Referencer is implemented by originers which values are stored outside of the
+// configuration file (environment variables, vault secrets). Reference returns
+// the external location which is consulted for the path.
+type Referencer interface {
+	Reference(context.Context, string) (string, bool)
+}
+
 type Source struct {
 	Originer
 	DirFs      fs.ReadDirFS
@@ -19,11 +31,26 @@ type Source struct {
 	Hostname   string
 	Deployment string
 	Instance   string
+	Tier       string
+	Dimensions []Dimension
+	Name       string
+	Namespace  string
 }
 
 func New(ctx context.Context, dirFs fs.ReadDirFS, fpath, hostname string) (*Source, error) {
-	fileName := file.FileName(fpath)
-	srcType, deployment, instance := ParseFilename(fileName, hostname)
+	return Parser{Hostname: hostname}.New(ctx, dirFs, fpath)
+}
+
+// New is the same as package New function except that the filename is recognized by the parser
+func (p Parser) New(ctx context.Context, dirFs fs.ReadDirFS, fpath string) (*Source, error) {
+	return p.NewNamed(ctx, dirFs, fpath, file.FileName(fpath))
+}
+
+// NewNamed is the same as New except that the name is recognized instead of the
+// filename. It is used for files of directories named like configuration files.
+func (p Parser) NewNamed(ctx context.Context, dirFs fs.ReadDirFS, fpath, name string) (*Source, error) {
+	name, dims := SplitDimensions(name)
+	srcType, deployment, instance := p.Parse(name)
 
 	o := &Source{
 		DirFs:      dirFs,
@@ -31,7 +58,9 @@ func New(ctx context.Context, dirFs fs.ReadDirFS, fpath, hostname string) (*Sour
 		Deployment: deployment,
 		Instance:   instance,
 		FilePath:   fpath,
-		Hostname:   hostname,
+		Hostname:   p.Hostname,
+		Dimensions: dims,
+		Name:       name,
 	}
 
 	return o, nil
@@ -18,9 +18,27 @@ const (
 	LocDepInstSrc
 	EnvSrc
 	VaultSrc
+	MemSrc
 )
 
+// TierSrc is the type of the first custom named tier, see TierType
+const TierSrc SourceType = 1 << 8
+
+// TierType returns the type of i-th custom tier
+func TierType(i int) SourceType {
+	return TierSrc + SourceType(i)
+}
+
+// IsTier reports if the type belongs to custom tier
+func (o SourceType) IsTier() bool {
+	return o >= TierSrc
+}
+
 func (o SourceType) String() string {
+	if o.IsTier() {
+		return "tier"
+	}
+
 	switch o {
 	case DefSrc:
 		return "default"
@@ -50,6 +68,8 @@ func (o SourceType) String() string {
 		return "environment"
 	case VaultSrc:
 		return "vault"
+	case MemSrc:
+		return "memory"
 	}
 
 	return "unknown"
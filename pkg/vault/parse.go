@@ -6,8 +6,10 @@ import "strings"
 
 const trimChars = "\t\r\n\x20"
 
+// parse "mount,secret[,key]" vault location. The key is configuration path
+// and may contain commas inside quotes or brackets.
 func parsePath(cfgPath string) (mount string, secret string, key string, err error) {
-	sepPath := strings.Split(cfgPath, ",")
+	sepPath := splitLocation(cfgPath)
 
 	switch len(sepPath) {
 	case 2:
@@ -18,3 +20,29 @@ func parsePath(cfgPath string) (mount string, secret string, key string, err err
 
 	return "", "", "", ErrInvalidPath
 }
+
+// split by commas outside of quoted or bracketed path keys
+func splitLocation(location string) []string {
+	var parts []string
+	var quoted, bracketed bool
+
+	start := 0
+
+	for i := 0; i < len(location); i++ {
+		switch c := location[i]; {
+		case quoted && c == '\\':
+			i++
+		case c == '"' && !bracketed:
+			quoted = !quoted
+		case c == '[' && !quoted:
+			bracketed = true
+		case c == ']' && !quoted:
+			bracketed = false
+		case c == ',' && !quoted && !bracketed:
+			parts = append(parts, location[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, location[start:])
+}
@@ -0,0 +1,23 @@
+//go:build goconfig_vault
+
+package vault
+
+import "testing"
+
+func TestParsePath(t *testing.T) {
+	t.Parallel()
+
+	mount, secret, key, err := parsePath(`secret, domains, "example.com,net".password`)
+	if err != nil || mount != "secret" || secret != "domains" || key != `"example.com,net".password` {
+		t.Fatal(mount, secret, key, err)
+	}
+
+	mount, secret, key, err = parsePath(`secret,domains,[a,b].password`)
+	if err != nil || mount != "secret" || secret != "domains" || key != `[a,b].password` {
+		t.Fatal(mount, secret, key, err)
+	}
+
+	if _, _, _, err := parsePath(`secret`); err != ErrInvalidPath {
+		t.Fatal(err)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"context"
 	"errors"
 	"io/fs"
+	"slices"
+	"strconv"
 
 	"github.com/boolka/goconfig/pkg/datamap"
 	vaultApi "github.com/hashicorp/vault/api"
@@ -33,15 +35,94 @@ func NewVaultSource(ctx context.Context, dirFs fs.ReadDirFS, fpath string, clien
 	}, nil
 }
 
+// Get looks up vault secret which location is stored by path. If path points
+// to a table or an array then all secrets beneath are returned. Wildcard path returns
+// the slice of all matched secrets.
 func (s *VaultSource) Get(ctx context.Context, path string) (any, bool) {
-	var d string
+	keys := datamap.SplitPath(path)
+	matches := datamap.MatchKeys(s.data, keys)
+	secrets := map[string]*vaultApi.KVSecret{}
 
-	v, ok := datamap.GetByPath(s.data, path)
-	if !ok {
-		return nil, false
+	if !datamap.HasWildcard(keys) {
+		if len(matches) == 0 {
+			return nil, false
+		}
+
+		return s.get(ctx, matches[0].Value, keys, secrets)
+	}
+
+	var values []any
+
+	for _, m := range matches {
+		v, ok := s.get(ctx, m.Value, m.Keys, secrets)
+		if !ok {
+			if err, isErr := v.(error); isErr {
+				return err, false
+			}
+
+			continue
+		}
+
+		values = append(values, v)
+	}
+
+	return values, len(values) > 0
+}
+
+func (s *VaultSource) get(ctx context.Context, v any, keys []string, secrets map[string]*vaultApi.KVSecret) (any, bool) {
+	switch v := v.(type) {
+	case map[string]any:
+		return s.resolve(ctx, v, keys, secrets)
+	case []any:
+		return s.resolveArray(ctx, v, keys, secrets)
+	}
+
+	return s.lookup(ctx, v, keys, secrets)
+}
+
+// resolve all secrets of the table, secrets are requested once per call
+func (s *VaultSource) resolve(ctx context.Context, data map[string]any, keys []string, secrets map[string]*vaultApi.KVSecret) (any, bool) {
+	resolved := map[string]any{}
+
+	for k, v := range data {
+		value, ok := s.get(ctx, v, append(slices.Clip(keys), k), secrets)
+		if !ok {
+			if err, isErr := value.(error); isErr {
+				return err, false
+			}
+
+			continue
+		}
+
+		resolved[k] = value
+	}
+
+	return resolved, len(resolved) > 0
+}
+
+// resolve all secrets of the array, missing secrets are omitted
+func (s *VaultSource) resolveArray(ctx context.Context, data []any, keys []string, secrets map[string]*vaultApi.KVSecret) (any, bool) {
+	var resolved []any
+
+	for i, v := range data {
+		value, ok := s.get(ctx, v, append(slices.Clip(keys), strconv.Itoa(i)), secrets)
+		if !ok {
+			if err, isErr := value.(error); isErr {
+				return err, false
+			}
+
+			continue
+		}
+
+		resolved = append(resolved, value)
 	}
 
-	if d, ok = v.(string); !ok {
+	return resolved, len(resolved) > 0
+}
+
+func (s *VaultSource) lookup(ctx context.Context, v any, keys []string, secrets map[string]*vaultApi.KVSecret) (any, bool) {
+	d, ok := v.(string)
+	if !ok {
 		return ErrInvalidPath, false
 	}
 
@@ -50,16 +131,51 @@ func (s *VaultSource) Get(ctx context.Context, path string) (any, bool) {
 		return err, false
 	}
 
-	secret, err := s.client.KVv2(vaultMount).Get(ctx, vaultPath)
+	secret, ok := secrets[vaultMount+"\x00"+vaultPath]
+	if !ok {
+		secret, err = s.client.KVv2(vaultMount).Get(ctx, vaultPath)
+		if err != nil && !errors.Is(err, vaultApi.ErrSecretNotFound) {
+			return err, false
+		}
+
+		// missing secret is not a failure, the path is just absent
+		secrets[vaultMount+"\x00"+vaultPath] = secret
+	}
+
+	if secret == nil {
+		return nil, false
+	}
+
+	// secret key defaults to the configuration path
+	if mapPath == "" {
+		return datamap.GetByKeys(secret.Data, keys)
+	}
+
+	return datamap.GetByPath(secret.Data, mapPath)
+}
+
+// Reference returns vault location in form of "mount,secret,key" stored by path
+func (s *VaultSource) Reference(_ context.Context, path string) (string, bool) {
+	v, ok := datamap.GetByPath(s.data, path)
+	if !ok {
+		return "", false
+	}
+
+	d, ok := v.(string)
+	if !ok {
+		return "", false
+	}
+
+	vaultMount, vaultPath, mapPath, err := parsePath(d)
 	if err != nil {
-		return err, false
+		return "", false
 	}
 
 	if mapPath == "" {
 		mapPath = path
 	}
 
-	return datamap.GetByPath(secret.Data, mapPath)
+	return vaultMount + "," + vaultPath + "," + mapPath, true
 }
 
 func (e *VaultSource) Client() *vaultApi.Client {
@@ -6,6 +6,7 @@ import (
 	"context"
 	"io/fs"
 	"os"
+	"reflect"
 	"testing"
 
 	"github.com/boolka/goconfig/pkg/vault"
@@ -62,6 +63,28 @@ func TestVaultSource(t *testing.T) {
 	if v, ok := cfg.Get(ctx, "userpass.password2"); !ok || v != "correct horse battery staple" {
 		t.Fatal(v, ok)
 	}
+	if r, ok := cfg.Reference(ctx, "userpass.password2"); !ok || r != "secret,goconfig_secret,password2" {
+		t.Fatal(r, ok)
+	}
+	if r, ok := cfg.Reference(ctx, "password1"); !ok || r != "secret,goconfig_secret,password1" {
+		t.Fatal(r, ok)
+	}
+	if v, ok := cfg.Get(ctx, "userpass.*"); !ok || !reflect.DeepEqual(v, []any{[]any{"correct horse battery staple"}, "correct horse battery staple"}) {
+		t.Fatal(v, ok)
+	}
+	if v, ok := cfg.Get(ctx, "userpass"); !ok || !reflect.DeepEqual(v, map[string]any{
+		"password2": "correct horse battery staple",
+		"list":      []any{"correct horse battery staple"},
+	}) {
+		t.Fatal(v, ok)
+	}
+	// missing secrets of the array are omitted
+	if v, ok := cfg.Get(ctx, "passwords"); !ok || !reflect.DeepEqual(v, []any{"abc123"}) {
+		t.Fatal(v, ok)
+	}
+	if v, ok := cfg.Get(ctx, "passwords.0"); !ok || v != "abc123" {
+		t.Fatal(v, ok)
+	}
 }
 
 func TestVaultUnauthClient(t *testing.T) {